@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/format"
 	"go/token"
+	"go/types"
 	"log"
+	"mime"
+	"path"
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/iancoleman/strcase"
 	"golang.org/x/tools/go/analysis"
@@ -26,38 +32,162 @@ var Analyzer = &analysis.Analyzer{
 
 const Doc = "upgrade a design definition for Goa from v1 to v3"
 
-var regexpWildcard = regexp.MustCompile(`/:([a-zA-Z0-9_]+)`)
+var (
+	regexpWildcard = regexp.MustCompile(`/:([a-zA-Z0-9_]+)`)
+	regexpCatchAll = regexp.MustCompile(`/\*([a-zA-Z0-9_]+)`)
+	regexpParam    = regexp.MustCompile(`/[:*]([a-zA-Z0-9_]+)`)
+)
+
+// formatNames maps the formats of v1 to the names of the format constants of v3.
+var formatNames = map[string]string{
+	"cidr":      "FormatCIDR",
+	"date":      "FormatDate",
+	"date-time": "FormatDateTime",
+	"email":     "FormatEmail",
+	"hostname":  "FormatHostname",
+	"ip":        "FormatIP",
+	"ipv4":      "FormatIPv4",
+	"ipv6":      "FormatIPv6",
+	"json":      "FormatJSON",
+	"mac":       "FormatMAC",
+	"regexp":    "FormatRegexp",
+	"rfc1123":   "FormatRFC1123",
+	"uri":       "FormatURI",
+	"uuid":      "FormatUUID",
+}
+
+// initialisms is the set of the words goagen writes in upper case in the names of generated types.
+var initialisms = map[string]bool{
+	"API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JMES": true, "JSON": true,
+	"JWT": true, "LHS": true, "OK": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true,
+	"SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true,
+	"VM": true, "XML": true, "XSRF": true, "XSS": true,
+}
+
+// statusNames maps HTTP status codes to the names of the response constants of v1.
+var statusNames = map[int]string{
+	100: "Continue", 101: "SwitchingProtocols",
+	200: "OK", 201: "Created", 202: "Accepted", 203: "NonAuthoritativeInfo", 204: "NoContent", 205: "ResetContent", 206: "PartialContent",
+	300: "MultipleChoices", 301: "MovedPermanently", 302: "Found", 303: "SeeOther", 304: "NotModified", 305: "UseProxy", 307: "TemporaryRedirect",
+	400: "BadRequest", 401: "Unauthorized", 402: "PaymentRequired", 403: "Forbidden", 404: "NotFound",
+	405: "MethodNotAllowed", 406: "NotAcceptable", 407: "ProxyAuthRequired", 408: "RequestTimeout", 409: "Conflict",
+	410: "Gone", 411: "LengthRequired", 412: "PreconditionFailed", 413: "RequestEntityTooLarge", 414: "RequestURITooLong",
+	415: "UnsupportedMediaType", 416: "RequestedRangeNotSatisfiable", 417: "ExpectationFailed", 418: "Teapot", 422: "UnprocessableEntity",
+	500: "InternalServerError", 501: "NotImplemented", 502: "BadGateway", 503: "ServiceUnavailable", 504: "GatewayTimeout", 505: "HTTPVersionNotSupported",
+}
+
+// definitions holds the declarations which are referred across the design package.
+type definitions struct {
+	schemes     map[string]*securityScheme // keyed by variable name
+	apiSecurity []*securityScheme
+	apiParams   []ast.Stmt
+	apiBasePath string
+	mediaTypes  map[string]*mediaType // keyed by variable name
+	responses   map[string]*responseTemplate
+	traits      map[string]*ast.BlockStmt
+	services    []string // names of the resources
+	resources   map[string]*resource
+	constDecls  []*ast.GenDecl         // constant declarations rewritten since they are used in paths
+	required    map[string][]string    // required attributes of the user types, keyed by variable name
+	expanded    map[*ast.CallExpr]bool // declarations in which UseTrait is expanded
+}
+
+// mediaType describes a media type declared by MediaType.
+type mediaType struct {
+	identifier string
+	attributes []string
+	views      []string
+}
+
+// inherited holds the DSLs which a method inherits from the enclosing Resource and API.
+type inherited struct {
+	security        []*securityScheme
+	noSecurity      bool // NoSecurity of the Resource overrides Security of the API
+	apiParams       []ast.Stmt
+	resourceParams  []ast.Stmt
+	resourceHeaders []ast.Stmt
+	defaultMedia    []ast.Expr         // arguments of DefaultMedia of the Resource
+	parentParams    []ast.Stmt         // Params for the path parameters of the canonical action of the Parent
+	parentPaths     map[string]string  // names of the path parameters of the Parent keyed by the names of the attributes
+	pathParams      []string           // names of the path parameters of BasePath
+	actions         map[string]*action // actions of the Resource collected in advance
+}
+
+// resource describes the routing of a resource declared by Resource.
+type resource struct {
+	basePath  string
+	parent    string
+	canonical string     // name of the canonical action
+	params    []ast.Stmt // Params of the resource
+	actions   map[string]*action
+}
+
+// action describes the routing of an action declared by Action.
+type action struct {
+	routes []string
+	params []ast.Stmt
+}
+
+// responseTemplate describes a named response declared by Response or ResponseTemplate in API.
+type responseTemplate struct {
+	params []string // names of the parameters of the template
+	body   *ast.BlockStmt
+}
+
+// securityScheme describes a security scheme declared by BasicAuthSecurity, APIKeySecurity, JWTSecurity or OAuth2Security.
+type securityScheme struct {
+	dsl    string   // name of the DSL declaring the scheme
+	name   string   // name of the scheme
+	in     string   // "header" or "query" if the scheme carries a key or a token
+	key    string   // name of the header or the query parameter
+	scopes []string // names of the declared scopes
+}
 
 func run(pass *analysis.Pass) (interface{}, error) {
+	defs := collectDefinitions(pass)
 	for _, file := range pass.Files {
+		cors := findNestedCall(file, "Origin") != nil
 		for _, decl := range file.Decls {
 			switch decl := decl.(type) {
 			case *ast.GenDecl:
 				switch decl.Tok {
 				case token.IMPORT:
-					analyzeAndFixImports(pass, decl)
+					analyzeAndFixImports(pass, decl, cors)
 				case token.VAR:
-					analyzeAndFixVariables(pass, decl)
+					analyzeAndFixVariables(pass, defs, decl)
 				}
 			case *ast.FuncDecl:
 				analyzeAndFixFuncs(pass, decl)
 			}
 		}
 	}
+	for _, decl := range defs.constDecls {
+		analyzeAndFixConstants(pass, decl)
+	}
 	return nil, nil
 }
 
-func analyzeAPI(pass *analysis.Pass, expr *ast.CallExpr) bool {
-	var changed bool
+func analyzeAPI(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr) bool {
+	var (
+		changed bool
+		name    string
+	)
+	if len(expr.Args) > 0 {
+		name, _ = stringValue(expr.Args[0])
+	}
 	for _, expr := range expr.Args {
 		expr, ok := expr.(*ast.FuncLit)
 		if !ok {
 			continue
 		}
-		analyzeGenericDSL(pass, expr)
+		analyzeGenericDSL(pass.Report, expr)
 		var (
 			listAPI     []ast.Stmt
 			listAPIHTTP []ast.Stmt
+			hosts       []*ast.CallExpr
+			schemes     []*ast.CallExpr
 		)
 		for _, stmt := range expr.Body.List {
 			stmt, ok := stmt.(*ast.ExprStmt)
@@ -77,14 +207,35 @@ func analyzeAPI(pass *analysis.Pass, expr *ast.CallExpr) bool {
 				changed = analyzeBasePath(pass, stmt, expr, ident, &listAPIHTTP) || changed
 			case "Consumes":
 				changed = analyzeConsumes(pass, stmt, &listAPIHTTP) || changed
+			case "Contact", "Docs", "License", "TermsOfService", "Title", "Version":
+				// The metadata of API is written the same in v3.
+				listAPI = append(listAPI, stmt)
+			case "Host":
+				hosts = append(hosts, expr)
+			case "NoSecurity":
+				changed = analyzeNoSecurity(pass, ident, "API", false) || changed
+			case "Origin":
+				changed = analyzeOrigin(pass, expr, ident) || changed
+				listAPI = append(listAPI, stmt)
 			case "Params":
-				changed = analyzeParams(pass, stmt, &listAPIHTTP) || changed
+				changed = analyzeParams(pass, stmt, "API") || changed
 			case "Produces":
 				changed = analyzeProduces(pass, stmt, &listAPIHTTP) || changed
+			case "Response", "ResponseTemplate":
+				if !analyzeResponseTemplate(pass, defs, expr, ident) {
+					listAPI = append(listAPI, stmt)
+					break
+				}
+				changed = true
+			case "Scheme":
+				schemes = append(schemes, expr)
+			case "Trait":
+				changed = analyzeTrait(pass, ident) || changed
 			default:
 				listAPI = append(listAPI, stmt)
 			}
 		}
+		changed = analyzeServer(pass, defs, name, hosts, schemes, &listAPI) || changed
 		if len(listAPIHTTP) > 0 {
 			listAPI = append(listAPI, &ast.ExprStmt{
 				X: &ast.CallExpr{
@@ -101,25 +252,42 @@ func analyzeAPI(pass *analysis.Pass, expr *ast.CallExpr) bool {
 					},
 				},
 			})
+		}
+		if changed {
 			expr.Body.List = listAPI
 		}
 	}
 	return changed
 }
 
-func analyzeAction(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr, ident *ast.Ident, parent *[]ast.Stmt) bool {
+func analyzeAction(pass *analysis.Pass, defs *definitions, inh *inherited, stmt *ast.ExprStmt, expr *ast.CallExpr, ident *ast.Ident, parent *[]ast.Stmt) bool {
 	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Action should be replaced with Method`})
 	ident.Name = "Method"
 	*parent = append(*parent, stmt)
+	pathParams := make(map[string]string)
+	for attr, name := range inh.parentPaths {
+		pathParams[attr] = name
+	}
+	names := inh.pathParams
+	if len(expr.Args) > 0 {
+		if name, ok := stringValue(expr.Args[0]); ok && inh.actions[name] != nil {
+			names = append(collectPathParams(inh.actions[name].routes...), names...)
+		}
+	}
+	for _, name := range names {
+		pathParams[name] = name
+	}
 	for _, expr := range expr.Args {
 		expr, ok := expr.(*ast.FuncLit)
 		if !ok {
 			continue
 		}
 		var (
+			changed        bool
 			listAction     []ast.Stmt
 			listActionHTTP []ast.Stmt
 		)
+		defaultResponse := hasOKResponse(pass, expr.Body.List)
 		for _, stmt := range expr.Body.List {
 			stmt, ok := stmt.(*ast.ExprStmt)
 			if !ok {
@@ -135,17 +303,42 @@ func analyzeAction(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr,
 			}
 			switch ident.Name {
 			case "Headers":
-				analyzeHeaders(pass, stmt, &listActionHTTP)
+				analyzeHeaders(pass, stmt, "Action")
 			case "Params":
-				analyzeParams(pass, stmt, &listActionHTTP)
+				analyzeParams(pass, stmt, "Action")
+			case "Payload", "OptionalPayload":
+				changed = analyzePayload(pass, defs, expr, ident) || changed
+				listAction = append(listAction, stmt)
 			case "Response":
-				analyzeResponse(pass, stmt, expr, &listActionHTTP, &listAction)
+				analyzeResponse(pass, defs, stmt, expr, &listActionHTTP, &listAction)
 			case "Routing":
-				analyzeRouting(pass, expr, &listActionHTTP)
+				analyzeRouting(pass, defs, expr, &listActionHTTP)
 			default:
 				listAction = append(listAction, stmt)
 			}
 		}
+		if routing := findCall(expr.Body.List, "Routing"); routing != nil {
+			analyzeParentParamConflicts(pass, routing, inh.parentPaths)
+		}
+		security, ok := collectSecurity(pass, defs, expr.Body.List)
+		if !ok {
+			security = inh.security
+		}
+		changed = analyzePayloadAttributes(pass, ident, collectGroup(expr.Body.List, "Params"), "Params", "", pathParams, &listAction, &listActionHTTP) || changed
+		changed = analyzePayloadAttributes(pass, ident, inh.resourceParams, "Params", "Resource", pathParams, &listAction, &listActionHTTP) || changed
+		changed = analyzePayloadAttributes(pass, ident, inh.apiParams, "Params", "API", pathParams, &listAction, &listActionHTTP) || changed
+		changed = analyzePayloadAttributes(pass, ident, inh.parentParams, "Params", "Parent", pathParams, &listAction, &listActionHTTP) || changed
+		declared := append(append(collectGroup(expr.Body.List, "Params"), inh.resourceParams...), inh.apiParams...)
+		changed = analyzePathParamAttributes(pass, ident, names, declared, pathParams, &listAction, &listActionHTTP) || changed
+		changed = analyzePayloadAttributes(pass, ident, collectGroup(expr.Body.List, "Headers"), "Headers", "", nil, &listAction, &listActionHTTP) || changed
+		changed = analyzePayloadAttributes(pass, ident, inh.resourceHeaders, "Headers", "Resource", nil, &listAction, &listActionHTTP) || changed
+		changed = analyzeSecuredPayload(pass, ident, security, &listAction, &listActionHTTP) || changed
+		changed = analyzeDefaultMediaResult(pass, defs, ident, inh.defaultMedia, defaultResponse, &listAction) || changed
+		if !ok && inh.noSecurity {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `NoSecurity should be added to Method`})
+			listAction = append(listAction, newCallStmt("NoSecurity"))
+			changed = true
+		}
 		if len(listActionHTTP) > 0 {
 			listAction = append(listAction, &ast.ExprStmt{
 				X: &ast.CallExpr{
@@ -162,13 +355,15 @@ func analyzeAction(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr,
 					},
 				},
 			})
+		}
+		if len(listActionHTTP) > 0 || changed {
 			expr.Body.List = listAction
 		}
 	}
 	return true
 }
 
-func analyzeAndFixImports(pass *analysis.Pass, decl *ast.GenDecl) {
+func analyzeAndFixImports(pass *analysis.Pass, decl *ast.GenDecl, cors bool) {
 	var changed bool
 	var specs []ast.Spec
 	for _, spec := range decl.Specs {
@@ -181,6 +376,9 @@ func analyzeAndFixImports(pass *analysis.Pass, decl *ast.GenDecl) {
 			specs = append(specs, spec)
 		}
 	}
+	if cors {
+		changed = analyzeCORSImport(pass, decl, &specs) || changed
+	}
 	if changed {
 		decl.Specs = specs
 		var b []byte
@@ -196,7 +394,7 @@ func analyzeAndFixImports(pass *analysis.Pass, decl *ast.GenDecl) {
 	}
 }
 
-func analyzeAndFixVariables(pass *analysis.Pass, decl *ast.GenDecl) {
+func analyzeAndFixVariables(pass *analysis.Pass, defs *definitions, decl *ast.GenDecl) {
 	var changed bool
 	for _, spec := range decl.Specs {
 		spec, ok := spec.(*ast.ValueSpec)
@@ -212,13 +410,16 @@ func analyzeAndFixVariables(pass *analysis.Pass, decl *ast.GenDecl) {
 			if !ok {
 				continue
 			}
+			changed = defs.expanded[expr] || changed
 			switch ident.Name {
 			case "API":
-				changed = analyzeAPI(pass, expr) || changed
+				changed = analyzeAPI(pass, defs, expr) || changed
+			case "APIKeySecurity", "BasicAuthSecurity", "JWTSecurity", "OAuth2Security":
+				changed = analyzeSecurityScheme(pass, expr, ident) || changed
 			case "MediaType":
-				changed = analyzeMediaType(pass, expr, ident) || changed
+				changed = analyzeMediaType(pass, defs, expr, ident) || changed
 			case "Resource":
-				changed = analyzeResource(pass, expr, ident) || changed
+				changed = analyzeResource(pass, defs, expr, ident) || changed
 			case "Type":
 				changed = analyzeType(pass, expr) || changed
 			}
@@ -234,9 +435,18 @@ func analyzeAndFixVariables(pass *analysis.Pass, decl *ast.GenDecl) {
 	}
 }
 
+func analyzeAndFixConstants(pass *analysis.Pass, decl *ast.GenDecl) {
+	pass.Report(analysis.Diagnostic{
+		Pos: decl.Pos(), Message: `constant declarations should be fixed`,
+		SuggestedFixes: []analysis.SuggestedFix{{Message: "Fix", TextEdits: []analysis.TextEdit{
+			{Pos: decl.Pos(), End: decl.End(), NewText: formatNode(pass.Fset, decl)},
+		}}},
+	})
+}
+
 func analyzeAndFixFuncs(pass *analysis.Pass, decl *ast.FuncDecl) {
 	body := decl.Body
-	changed := analyzeGenericDSL(pass, body)
+	changed := analyzeGenericDSL(pass.Report, body)
 	if changed {
 		decl.Body = body
 		b := formatNode(pass.Fset, decl)
@@ -249,7 +459,7 @@ func analyzeAndFixFuncs(pass *analysis.Pass, decl *ast.FuncDecl) {
 	}
 }
 
-func analyzeAttribute(pass *analysis.Pass, expr *ast.CallExpr) bool {
+func analyzeAttribute(report func(analysis.Diagnostic), expr *ast.CallExpr) bool {
 	var changed bool
 	for _, e := range expr.Args {
 		ident, ok := e.(*ast.Ident)
@@ -257,8 +467,8 @@ func analyzeAttribute(pass *analysis.Pass, expr *ast.CallExpr) bool {
 			continue
 		}
 		switch ident.Name {
-		case "DateTime":
-			changed = analyzeDateTime(pass, expr, ident) || changed
+		case "DateTime", "UUID":
+			changed = analyzeFormattedType(report, ident, lastFuncLit(expr)) || changed
 		}
 	}
 	return changed
@@ -295,49 +505,176 @@ func analyzeConsumes(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt
 	return true
 }
 
-func analyzeDateTime(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `DateTime should be replaced with String + Format(FormatDateTime)`})
+// analyzeFormattedType replaces ident of a type with a format with String, and adds Format to dsl of the attribute owning the type.
+func analyzeFormattedType(report func(analysis.Diagnostic), ident *ast.Ident, dsl *ast.FuncLit) bool {
+	format := "Format" + ident.Name
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s should be replaced with String + Format(%s)`, ident.Name, format)})
 	ident.Name = "String"
-	e, ok := expr.Args[len(expr.Args)-1].(*ast.FuncLit)
+	dsl.Body.List = append(dsl.Body.List, newCallStmt("Format", &ast.Ident{Name: format}))
+	return true
+}
+
+func analyzeDefaultMedia(pass *analysis.Pass, ident *ast.Ident) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `DefaultMedia should be replaced with Result of each Method`})
+	return true
+}
+
+// analyzeDefaultMediaResult applies media given to DefaultMedia to the method as Result if it has a response with OK and no media,
+// or as Extend of Result if Result only holds the attributes for the headers,
+// and as Reference of the payload if it refers attributes of the media by name.
+func analyzeDefaultMediaResult(pass *analysis.Pass, defs *definitions, ident *ast.Ident, media []ast.Expr, defaultResponse bool, parent *[]ast.Stmt) bool {
+	if len(media) == 0 {
+		return false
+	}
+	var changed bool
+	if defaultResponse {
+		if result := findCall(*parent, "Result"); result == nil {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Result should be added for DefaultMedia of Resource`})
+			args := make([]ast.Expr, len(media))
+			for i, e := range media {
+				args[i] = cloneExpr(e)
+			}
+			*parent = append(*parent, newCallStmt("Result", args...))
+			changed = true
+		} else if dsl := headerResult(result); dsl != nil {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Extend should be added to Result for DefaultMedia of Resource`})
+			list := []ast.Stmt{newCallStmt("Extend", cloneExpr(media[0]))}
+			if len(media) > 1 {
+				if e, ok := cloneExpr(media[1]).(*ast.FuncLit); ok {
+					list = append(list, e.Body.List...)
+				}
+			}
+			dsl.Body.List = append(list, dsl.Body.List...)
+			changed = true
+		}
+	}
+	i, ok := media[0].(*ast.Ident)
 	if !ok {
-		e = &ast.FuncLit{
-			Type: &ast.FuncType{},
-			Body: &ast.BlockStmt{},
+		return changed
+	}
+	mt, ok := defs.mediaTypes[i.Name]
+	if !ok {
+		return changed
+	}
+	payload := findCall(*parent, "Payload")
+	if payload == nil || len(payload.Args) != 1 {
+		return changed
+	}
+	dsl, ok := payload.Args[0].(*ast.FuncLit)
+	if !ok || findCall(dsl.Body.List, "Extend") != nil || findCall(dsl.Body.List, "Reference") != nil {
+		return changed
+	}
+	for _, stmt := range dsl.Body.List {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); !ok || ident.Name != "Attribute" {
+			continue
+		}
+		if len(expr.Args) > 1 {
+			switch expr.Args[1].(type) {
+			case *ast.BasicLit, *ast.FuncLit:
+			default:
+				continue
+			}
+		}
+		if name, ok := stringValue(expr.Args[0]); ok && hasString(mt.attributes, name) {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Reference should be added to Payload for DefaultMedia of Resource`})
+			dsl.Body.List = append([]ast.Stmt{newCallStmt("Reference", cloneExpr(media[0]))}, dsl.Body.List...)
+			return true
 		}
-		expr.Args = append(expr.Args, e)
 	}
-	e.Body.List = append(e.Body.List, &ast.ExprStmt{
-		X: &ast.CallExpr{
-			Fun: &ast.Ident{
-				Name: "Format",
-			},
-			Args: []ast.Expr{
-				&ast.Ident{
-					Name: "FormatDateTime",
-				},
-			},
-		},
-	})
+	return changed
+}
+
+// analyzeError returns Error of the method in parent for the status of an error response, adding it if the method has no such Error.
+func analyzeError(name string, parent *[]ast.Stmt) *ast.CallExpr {
+	for _, stmt := range *parent {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		if i, ok := expr.Fun.(*ast.Ident); !ok || i.Name != "Error" {
+			continue
+		}
+		if v, ok := stringValue(expr.Args[0]); ok && v == name {
+			return expr
+		}
+	}
+	stmt := newCallStmt("Error", newStringLit(name))
+	*parent = append(*parent, stmt)
+	return stmt.X.(*ast.CallExpr)
+}
+
+// analyzeErrorMedia moves media of an error response to the type of Error, and reports whether it is moved or not.
+func analyzeErrorMedia(pass *analysis.Pass, media ast.Expr, errorExpr *ast.CallExpr) bool {
+	if len(errorExpr.Args) > 1 {
+		if !bytes.Equal(formatNode(pass.Fset, errorExpr.Args[1]), formatNode(pass.Fset, media)) {
+			pass.Report(analysis.Diagnostic{Pos: media.Pos(), Message: `media of the error response conflicts with Error of another response and should be fixed manually`})
+			return false
+		}
+		return true
+	}
+	pass.Report(analysis.Diagnostic{Pos: media.Pos(), Message: `media of an error response should be moved to Error`})
+	errorExpr.Args = append(errorExpr.Args, media)
 	return true
 }
 
-func analyzeDefaultMedia(pass *analysis.Pass, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `DefaultMedia should be removed`})
+// analyzeFiles replaces the wildcards in the path of Files, and reports a file which goagen generated.
+func analyzeFiles(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr) bool {
+	if len(expr.Args) < 2 {
+		return false
+	}
+	changed := analyzePath(pass, defs, expr.Args[0])
+	if filename, ok := stringValue(expr.Args[1]); ok {
+		switch path.Base(filename) {
+		case "swagger.json", "swagger.yaml":
+			pass.Report(analysis.Diagnostic{Pos: expr.Args[1].Pos(), Message: fmt.Sprintf(`%s is no longer generated and should be replaced with openapi%s generated in gen/http`, filename, path.Ext(filename))})
+		}
+	}
+	return changed
+}
+
+func analyzeFormat(report func(analysis.Diagnostic), expr *ast.CallExpr) bool {
+	if len(expr.Args) == 0 {
+		return false
+	}
+	format, ok := stringValue(expr.Args[0])
+	if !ok {
+		return false
+	}
+	name, ok := formatNames[format]
+	if !ok {
+		report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`format %q has no equivalent in v3 and should be fixed manually`, format)})
+		return false
+	}
+	report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`%q should be replaced with %s`, format, name)})
+	expr.Args[0] = &ast.Ident{NamePos: expr.Args[0].Pos(), Name: name}
 	return true
 }
 
-func analyzeGenericDSL(pass *analysis.Pass, node ast.Node) bool {
+// analyzeGenericDSL converts the types and the DSLs which may appear anywhere in node, and calls report with each diagnostic.
+func analyzeGenericDSL(report func(analysis.Diagnostic), node ast.Node) bool {
 	var changed bool
 	ast.Inspect(node, func(n ast.Node) bool {
 		switch expr := n.(type) {
 		case *ast.Ident:
 			switch expr.Name {
 			case "Integer":
-				changed = analyzeInteger(pass, expr) || changed
+				changed = analyzeInteger(report, expr) || changed
 			case "Number":
-				changed = analyzeNumber(pass, expr) || changed
+				changed = analyzeNumber(report, expr) || changed
 			case "File":
-				changed = analyzeFile(pass, expr) || changed
+				changed = analyzeFile(report, expr) || changed
 			}
 		case *ast.CallExpr:
 			ident, ok := expr.Fun.(*ast.Ident)
@@ -346,13 +683,18 @@ func analyzeGenericDSL(pass *analysis.Pass, node ast.Node) bool {
 			}
 			switch ident.Name {
 			case "Attribute":
-				changed = analyzeAttribute(pass, expr) || changed
+				changed = analyzeAttribute(report, expr) || changed
+			case "Member":
+				changed = analyzeMember(report, ident) || changed
+				changed = analyzeAttribute(report, expr) || changed
+			case "Format":
+				changed = analyzeFormat(report, expr) || changed
 			case "HashOf":
-				changed = analyzeHashOf(pass, expr, ident) || changed
+				changed = analyzeHashOf(report, expr, ident) || changed
 			case "Metadata":
-				changed = analyzeMetadata(pass, ident) || changed
+				changed = analyzeMetadata(report, ident) || changed
 			default:
-				changed = analyzeAttribute(pass, expr) || changed
+				changed = analyzeAttribute(report, expr) || changed
 			}
 		}
 		return true
@@ -360,20 +702,169 @@ func analyzeGenericDSL(pass *analysis.Pass, node ast.Node) bool {
 	return changed
 }
 
-func analyzeHTTPRoutingDSL(pass *analysis.Pass, expr *ast.CallExpr) bool {
-	var changed bool
+func analyzeHTTPRoutingDSL(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, ident *ast.Ident, parent *[]ast.Stmt) bool {
+	var (
+		changed bool
+		args    []ast.Expr
+	)
 	for _, e := range expr.Args {
-		e, ok := e.(*ast.BasicLit)
-		if !ok {
+		if e, ok := e.(*ast.FuncLit); ok {
+			pass.Report(analysis.Diagnostic{Pos: e.Pos(), Message: fmt.Sprintf(`DSL of %s should be moved to HTTP`, ident.Name)})
+			*parent = append(*parent, e.Body.List...)
+			changed = true
 			continue
 		}
-		replaced := replaceWildcard(e.Value)
-		if replaced != e.Value {
-			pass.Report(analysis.Diagnostic{Pos: e.Pos(), Message: `colons in HTTP routing DSLs should be replaced with curly braces`})
-			e.Value = replaced
-			changed = true
+		changed = analyzePath(pass, defs, e) || changed
+		args = append(args, e)
+	}
+	expr.Args = args
+	return changed
+}
+
+// analyzePath replaces the wildcards in the path built by expr.
+// A constant in the path is rewritten at its declaration.
+func analyzePath(pass *analysis.Pass, defs *definitions, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return analyzePathLiteral(pass, e)
+	case *ast.BinaryExpr:
+		changedX := analyzePath(pass, defs, e.X)
+		changedY := analyzePath(pass, defs, e.Y)
+		return changedX || changedY
+	case *ast.ParenExpr:
+		return analyzePath(pass, defs, e.X)
+	case *ast.Ident:
+		analyzePathConstant(pass, defs, e)
+	case *ast.SelectorExpr:
+		analyzePathConstant(pass, defs, e.Sel)
+	default:
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `path should be checked for wildcards and fixed manually`})
+	}
+	return false
+}
+
+// analyzePathConstant replaces the wildcards in the declaration of the constant referred by ident.
+func analyzePathConstant(pass *analysis.Pass, defs *definitions, ident *ast.Ident) {
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Const)
+	if !ok {
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s should be checked for wildcards and fixed manually`, ident.Name)})
+		return
+	}
+	if obj.Val().Kind() != constant.String {
+		return
+	}
+	value := constant.StringVal(obj.Val())
+	if replaceCatchAll(replaceWildcard(value)) == value {
+		return
+	}
+	if obj.Pkg() == pass.Pkg {
+		if !usedOnlyInPaths(pass, obj, make(map[types.Object]bool)) {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s is used outside HTTP routing DSLs and its wildcards should be fixed manually`, ident.Name)})
+			return
+		}
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				decl, ok := decl.(*ast.GenDecl)
+				if !ok || decl.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, name := range spec.Names {
+						if pass.TypesInfo.Defs[name] != obj || i >= len(spec.Values) {
+							continue
+						}
+						if analyzePath(pass, defs, spec.Values[i]) {
+							for _, d := range defs.constDecls {
+								if d == decl {
+									return
+								}
+							}
+							defs.constDecls = append(defs.constDecls, decl)
+						}
+						return
+					}
+				}
+			}
+		}
+	}
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`wildcards in %s should be fixed manually`, ident.Name)})
+}
+
+// usedOnlyInPaths reports whether every use of the constant is in the paths of HTTP routing DSLs,
+// either directly or through the values of other constants used only in them.
+func usedOnlyInPaths(pass *analysis.Pass, obj types.Object, visited map[types.Object]bool) bool {
+	if visited[obj] {
+		return true
+	}
+	visited[obj] = true
+	only := true
+	for _, file := range pass.Files {
+		var stack []ast.Node
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+			stack = append(stack, n)
+			if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj && !inPath(pass, stack, visited) {
+				only = false
+			}
+			return true
+		})
+	}
+	return only
+}
+
+// inPath reports whether the last node of stack is in the path of an HTTP routing DSL, or in the value of a constant used only in them.
+func inPath(pass *analysis.Pass, stack []ast.Node, visited map[types.Object]bool) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.BinaryExpr, *ast.ParenExpr:
+			continue
+		case *ast.CallExpr:
+			ident, ok := n.Fun.(*ast.Ident)
+			if !ok {
+				return false
+			}
+			switch ident.Name {
+			case "GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH", "Files":
+				return true
+			}
+			return false
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				obj, ok := pass.TypesInfo.Defs[name].(*types.Const)
+				if !ok || !usedOnlyInPaths(pass, obj, visited) {
+					return false
+				}
+			}
+			return true
+		default:
+			return false
 		}
 	}
+	return false
+}
+
+// analyzePathLiteral replaces the wildcards in lit.
+func analyzePathLiteral(pass *analysis.Pass, e *ast.BasicLit) bool {
+	var changed bool
+	replaced := replaceWildcard(e.Value)
+	if replaced != e.Value {
+		pass.Report(analysis.Diagnostic{Pos: e.Pos(), Message: `colons in HTTP routing DSLs should be replaced with curly braces`})
+		e.Value = replaced
+		changed = true
+	}
+	replaced = replaceCatchAll(e.Value)
+	if replaced != e.Value {
+		pass.Report(analysis.Diagnostic{Pos: e.Pos(), Message: `asterisks in HTTP routing DSLs should be wrapped by curly braces`})
+		e.Value = replaced
+		changed = true
+	}
 	return changed
 }
 
@@ -384,46 +875,53 @@ func analyzeHTTPStatusConstant(pass *analysis.Pass, ident *ast.Ident) bool {
 	return true
 }
 
-func analyzeHashOf(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `HashOf should be replaced with MapOf`})
+func analyzeHashOf(report func(analysis.Diagnostic), expr *ast.CallExpr, ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `HashOf should be replaced with MapOf`})
 	ident.Name = "MapOf"
 	var (
 		changed bool
 		args    []ast.Expr
 		list    []ast.Stmt
+		dsls    [2]*ast.FuncLit // DSLs for the key and the value
 	)
 	for i, expr := range expr.Args {
 		switch i {
 		case 2:
-			pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `optional DSL for key of HashOf should be set by Key`})
-			list = append(list, &ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.Ident{
-						Name: "Key",
-					},
-					Args: []ast.Expr{
-						expr,
-					},
-				},
-			})
+			report(analysis.Diagnostic{Pos: expr.Pos(), Message: `optional DSL for key of HashOf should be set by Key`})
+			list = append(list, newCallStmt("Key", expr))
+			dsls[0], _ = expr.(*ast.FuncLit)
 			changed = true
 		case 3:
-			pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `optional DSL for value of HashOf should be set by Elem`})
-			list = append(list, &ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.Ident{
-						Name: "Elem",
-					},
-					Args: []ast.Expr{
-						expr,
-					},
-				},
-			})
+			report(analysis.Diagnostic{Pos: expr.Pos(), Message: `optional DSL for value of HashOf should be set by Elem`})
+			list = append(list, newCallStmt("Elem", expr))
+			dsls[1], _ = expr.(*ast.FuncLit)
 			changed = true
 		default:
 			args = append(args, expr)
 		}
 	}
+	for i, name := range []string{"Key", "Elem"} {
+		if i >= len(args) {
+			break
+		}
+		ident, ok := args[i].(*ast.Ident)
+		if !ok || (ident.Name != "DateTime" && ident.Name != "UUID") {
+			continue
+		}
+		if dsls[i] == nil {
+			if findCall(list, name) != nil {
+				report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s should be converted manually`, ident.Name)})
+				continue
+			}
+			dsls[i] = &ast.FuncLit{
+				Type: &ast.FuncType{},
+				Body: &ast.BlockStmt{},
+			}
+			list = append(list, newCallStmt(name, dsls[i]))
+		}
+		analyzeFormattedType(report, ident, dsls[i])
+		changed = true
+	}
 	if len(list) > 0 {
 		args = append(args, &ast.FuncLit{
 			Type: &ast.FuncType{},
@@ -438,9 +936,34 @@ func analyzeHashOf(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bo
 	return true
 }
 
-func analyzeHeaders(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
-	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Headers should be wrapped by HTTP`})
-	*parent = append(*parent, stmt)
+func analyzeHeaders(pass *analysis.Pass, stmt *ast.ExprStmt, parent string) bool {
+	if parent == "Action" {
+		pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Headers should be replaced with Payload attributes and Header mappings in HTTP`})
+	} else {
+		pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Headers should be moved to Payload of each Method and mapped by HTTP`})
+	}
+	return true
+}
+
+// analyzeCORSImport adds the DSL package of the CORS plugin to specs if they import the DSL package of Goa.
+func analyzeCORSImport(pass *analysis.Pass, decl *ast.GenDecl, specs *[]ast.Spec) bool {
+	var found bool
+	for _, spec := range *specs {
+		switch spec.(*ast.ImportSpec).Path.Value {
+		case `"goa.design/plugins/v3/cors/dsl"`:
+			return false
+		case `"goa.design/goa/v3/dsl"`:
+			found = true
+		}
+	}
+	if !found {
+		return false
+	}
+	pass.Report(analysis.Diagnostic{Pos: decl.Pos(), Message: `"goa.design/plugins/v3/cors/dsl" should be imported as cors for Origin`})
+	*specs = append(*specs, &ast.ImportSpec{
+		Name: &ast.Ident{Name: "cors"},
+		Path: newStringLit("goa.design/plugins/v3/cors/dsl"),
+	})
 	return true
 }
 
@@ -463,82 +986,466 @@ func analyzeImport(pass *analysis.Pass, spec *ast.ImportSpec) bool {
 	return changed
 }
 
-func analyzeInteger(pass *analysis.Pass, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Integer should be replaced with Int`})
+func analyzeInteger(report func(analysis.Diagnostic), ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Integer should be replaced with Int`})
 	ident.Name = "Int"
 	return true
 }
 
-func analyzeNumber(pass *analysis.Pass, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Number should be replaced with Float64`})
+func analyzeNoSecurity(pass *analysis.Pass, ident *ast.Ident, parent string, inherited bool) bool {
+	if inherited {
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`NoSecurity in %s should be moved to each Method`, parent)})
+	} else {
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`NoSecurity in %s should be removed`, parent)})
+	}
+	return true
+}
+
+func analyzeNumber(report func(analysis.Diagnostic), ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Number should be replaced with Float64`})
 	ident.Name = "Float64"
 	return true
 }
 
-func analyzeFile(pass *analysis.Pass, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `File should be replaced with Bytes`})
+func analyzeFile(report func(analysis.Diagnostic), ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `File should be replaced with Bytes`})
 	ident.Name = "Bytes"
 	return true
 }
 
-func analyzeMedia(pass *analysis.Pass, stmt *ast.ExprStmt, ident *ast.Ident, parent *[]ast.Stmt, errorResponse bool) bool {
+func analyzeMedia(pass *analysis.Pass, defs *definitions, stmt *ast.ExprStmt, ident *ast.Ident, parent *[]ast.Stmt, errorResponse bool, errorExpr *ast.CallExpr) bool {
+	expr, ok := stmt.X.(*ast.CallExpr)
+	if !ok || len(expr.Args) == 0 {
+		return false
+	}
 	if errorResponse {
-		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Media for an error response should be removed`})
+		media := expr.Args[0]
+		if sel, ok := designIdent(pass, media); ok {
+			media = sel
+		}
+		if i, ok := media.(*ast.Ident); errorExpr == nil || (ok && i.Name == "ErrorMedia") {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Media for an error response should be removed`})
+		} else {
+			analyzeErrorMedia(pass, expr.Args[0], errorExpr)
+		}
 	} else {
 		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Media for a non-error response should be replaced with Result and wrapped by HTTP in the parent`})
 		ident.Name = "Result"
-		*parent = append(*parent, stmt)
+		analyzeMediaTypeIdentifier(pass, defs, expr)
+		if len(expr.Args) > 1 {
+			analyzeView(pass, defs, expr)
+		}
+		if exists, _ := analyzeResultConflict(pass, expr.Args[0], *parent); !exists {
+			*parent = append(*parent, stmt)
+		}
 	}
 	return true
 }
 
-func analyzeMediaType(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `MediaType should be replaced with ResultType`})
-	ident.Name = "ResultType"
-	for _, expr := range expr.Args {
-		expr, ok := expr.(*ast.FuncLit)
-		if !ok {
-			continue
+// analyzeMediaTypeIdentifier replaces the media type identifier given to Media with the variable of the media type.
+func analyzeMediaTypeIdentifier(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr) bool {
+	identifier, ok := stringValue(expr.Args[0])
+	if !ok {
+		return false
+	}
+	var names []string
+	for name, mt := range defs.mediaTypes {
+		if mt.identifier == identifier {
+			names = append(names, name)
 		}
-		analyzeGenericDSL(pass, expr)
 	}
+	if len(names) != 1 {
+		return false
+	}
+	pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`media type identifier should be replaced with %s`, names[0])})
+	expr.Args[0] = &ast.Ident{NamePos: expr.Args[0].Pos(), Name: names[0]}
 	return true
 }
 
-func analyzeMetadata(pass *analysis.Pass, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Metadata should be replaced with Meta`})
-	ident.Name = "Meta"
-	return true
-}
-
-func analyzeParams(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
-	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Params should be wrapped by HTTP`})
-	*parent = append(*parent, stmt)
-	return true
-}
-
-func analyzeParent(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
-	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Parent should be wrapped by HTTP`})
-	*parent = append(*parent, stmt)
-	return true
-}
-
-func analyzeProduces(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
-	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Produces should be wrapped by HTTP`})
-	*parent = append(*parent, stmt)
+// analyzeView replaces the view name given to Media or DefaultMedia with View in the DSL of Result.
+func analyzeView(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr) bool {
+	view, ok := stringValue(expr.Args[1])
+	if !ok {
+		return false
+	}
+	pass.Report(analysis.Diagnostic{Pos: expr.Args[1].Pos(), Message: `view should be set by View in the DSL of Result`})
+	media := expr.Args[0]
+	if e, ok := media.(*ast.CallExpr); ok && len(e.Args) > 0 {
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "CollectionOf" {
+			media = e.Args[0]
+		}
+	}
+	if ident, ok := media.(*ast.Ident); ok {
+		if mt, ok := defs.mediaTypes[ident.Name]; ok && !hasString(mt.views, view) {
+			pass.Report(analysis.Diagnostic{Pos: expr.Args[1].Pos(), Message: fmt.Sprintf(`view %q is not defined in %s`, view, ident.Name)})
+		}
+	}
+	expr.Args = []ast.Expr{expr.Args[0], &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{newCallStmt("View", newStringLit(view))},
+		},
+	}}
 	return true
 }
 
-func analyzeResource(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Resource should be replaced with Service`})
-	ident.Name = "Service"
+func analyzeMediaType(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, ident *ast.Ident) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `MediaType should be replaced with ResultType`})
+	ident.Name = "ResultType"
+	var typeName string
+	if len(expr.Args) > 0 {
+		typeName = analyzeIdentifier(pass, expr.Args[0])
+	}
 	for _, expr := range expr.Args {
 		expr, ok := expr.(*ast.FuncLit)
 		if !ok {
 			continue
 		}
-		analyzeGenericDSL(pass, expr)
+		analyzeGenericDSL(pass.Report, expr)
+		if typeName != "" && findCall(expr.Body.List, "TypeName") == nil {
+			pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: fmt.Sprintf(`TypeName %q should be added to keep the name of the type generated by goagen`, typeName)})
+			expr.Body.List = append([]ast.Stmt{newCallStmt("TypeName", newStringLit(typeName))}, expr.Body.List...)
+		}
+		var list []ast.Stmt
+		for _, stmt := range expr.Body.List {
+			if e := findCall([]ast.Stmt{stmt}, "Links"); e != nil {
+				analyzeLinks(pass, defs, e, expr.Body, &list)
+				continue
+			}
+			list = append(list, stmt)
+		}
+		expr.Body.List = list
+	}
+	return true
+}
+
+// analyzeIdentifier canonicalizes the media type identifier in the way goagen does,
+// and returns the name of the type goagen generates for it, or an empty string if it cannot be determined.
+func analyzeIdentifier(pass *analysis.Pass, expr ast.Expr) string {
+	identifier, ok := stringValue(expr)
+	if !ok {
+		if identifier, ok = constantString(pass, expr); !ok {
+			return ""
+		}
+	}
+	mediaType, params, err := mime.ParseMediaType(identifier)
+	if err != nil {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: fmt.Sprintf(`identifier %q is invalid and should be fixed manually`, identifier)})
+		return ""
+	}
+	if canonical := mime.FormatMediaType(mediaType, params); canonical != "" && canonical != identifier {
+		if lit, ok := expr.(*ast.BasicLit); ok {
+			pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: fmt.Sprintf(`identifier should be replaced with %q`, canonical)})
+			lit.Value = strconv.Quote(canonical)
+		}
+	}
+	name := mediaType[strings.LastIndex(mediaType, "/")+1:]
+	if i := strings.Index(name, "+"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "vnd.")
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
+}
+
+// analyzeLinks replaces Links in the media type declared by body with attribute "links" of the linked attributes.
+func analyzeLinks(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, body *ast.BlockStmt, parent *[]ast.Stmt) bool {
+	pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `Links should be replaced with attribute "links"`})
+	attributes := body
+	if e := findCall(body.List, "Attributes"); e != nil && len(e.Args) > 0 {
+		if e, ok := e.Args[0].(*ast.FuncLit); ok {
+			attributes = e.Body
+		}
+	}
+	if hasAttribute(attributes.List, "links") {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `attribute "links" conflicts with Links and should be fixed manually`})
+		*parent = append(*parent, &ast.ExprStmt{X: expr})
+		return false
+	}
+	var list []ast.Stmt
+	for _, e := range expr.Args {
+		e, ok := e.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		for _, stmt := range e.Body.List {
+			link := findCall([]ast.Stmt{stmt}, "Link")
+			if link == nil || len(link.Args) == 0 {
+				continue
+			}
+			name, ok := stringValue(link.Args[0])
+			if !ok {
+				pass.Report(analysis.Diagnostic{Pos: link.Pos(), Message: `Link should be converted manually`})
+				continue
+			}
+			typ := findAttributeType(attributes.List, name)
+			if typ == nil {
+				pass.Report(analysis.Diagnostic{Pos: link.Args[0].Pos(), Message: fmt.Sprintf(`attribute %q linked by Link is not defined and should be fixed manually`, name)})
+				continue
+			}
+			view := "link"
+			if len(link.Args) > 1 {
+				if v, ok := stringValue(link.Args[1]); ok {
+					view = v
+				}
+			}
+			media := typ
+			if e, ok := media.(*ast.CallExpr); ok && len(e.Args) > 0 {
+				if i, ok := e.Fun.(*ast.Ident); ok && i.Name == "CollectionOf" {
+					media = e.Args[0]
+				}
+			}
+			if i, ok := media.(*ast.Ident); ok {
+				if mt, ok := defs.mediaTypes[i.Name]; ok && !hasString(mt.views, view) {
+					pass.Report(analysis.Diagnostic{Pos: link.Args[0].Pos(), Message: fmt.Sprintf(`view %q is not defined in %s`, view, i.Name)})
+				}
+			}
+			pass.Report(analysis.Diagnostic{Pos: link.Args[0].Pos(), Message: fmt.Sprintf(`JSON shape of link %q should be checked since it is rendered by view %q in attribute "links"`, name, view)})
+			list = append(list, newCallStmt("Attribute", newStringLit(name), cloneExpr(typ), &ast.FuncLit{
+				Type: &ast.FuncType{},
+				Body: &ast.BlockStmt{List: []ast.Stmt{newCallStmt("View", newStringLit(view))}},
+			}))
+		}
+	}
+	links := newCallStmt("Attribute", newStringLit("links"), &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: &ast.BlockStmt{List: list},
+	})
+	if attributes == body {
+		*parent = append(*parent, links)
+	} else {
+		attributes.List = append(attributes.List, links)
+	}
+	return true
+}
+
+func analyzeMember(report func(analysis.Diagnostic), ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Member should be replaced with Attribute`})
+	ident.Name = "Attribute"
+	return true
+}
+
+func analyzeMetadata(report func(analysis.Diagnostic), ident *ast.Ident) bool {
+	report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Metadata should be replaced with Meta`})
+	ident.Name = "Meta"
+	return true
+}
+
+func analyzeOAuth2Flow(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
+	var urls int
+	switch ident.Name {
+	case "AccessCodeFlow":
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `AccessCodeFlow should be replaced with AuthorizationCodeFlow`})
+		ident.Name = "AuthorizationCodeFlow"
+		urls = 2
+	case "ApplicationFlow":
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `ApplicationFlow should be replaced with ClientCredentialsFlow`})
+		ident.Name = "ClientCredentialsFlow"
+		urls = 1
+	default:
+		urls = 1
+	}
+	if len(expr.Args) == urls {
+		pass.Report(analysis.Diagnostic{Pos: expr.Rparen, Message: fmt.Sprintf(`refresh URL should be added to %s`, ident.Name)})
+		expr.Args = append(expr.Args, newStringLit(""))
+	}
+	return true
+}
+
+// analyzeOrigin qualifies Origin and the DSLs in it with the package of the CORS plugin.
+func analyzeOrigin(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Origin should be replaced with cors.Origin`})
+	expr.Fun = &ast.SelectorExpr{X: &ast.Ident{NamePos: ident.Pos(), Name: "cors"}, Sel: ident}
+	for _, e := range expr.Args {
+		e, ok := e.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		for _, stmt := range e.Body.List {
+			stmt, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			call, ok := stmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			i, ok := call.Fun.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch i.Name {
+			case "Credentials", "Expose", "Headers", "MaxAge", "Methods":
+				pass.Report(analysis.Diagnostic{Pos: i.Pos(), Message: fmt.Sprintf(`%s should be replaced with cors.%s`, i.Name, i.Name)})
+				call.Fun = &ast.SelectorExpr{X: &ast.Ident{NamePos: i.Pos(), Name: "cors"}, Sel: i}
+			}
+		}
+	}
+	return true
+}
+
+func analyzeParams(pass *analysis.Pass, stmt *ast.ExprStmt, parent string) bool {
+	if parent == "Action" {
+		pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Params should be replaced with Payload attributes and Param mappings in HTTP`})
+	} else {
+		pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Params should be moved to Payload of each Method and mapped by HTTP`})
+	}
+	return true
+}
+
+// analyzePayload replaces OptionalPayload with Payload, and reports Required in the DSL of the payload whose meaning changes.
+// OptionalPayload of v1 makes only the request body optional, which Payload of v3 cannot express.
+func analyzePayload(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, ident *ast.Ident) bool {
+	var (
+		changed bool
+		typ     string
+	)
+	optional := ident.Name == "OptionalPayload"
+	if optional {
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `OptionalPayload should be replaced with Payload`})
+		ident.Name = "Payload"
+		changed = true
+	}
+	for _, e := range expr.Args {
+		switch e := e.(type) {
+		case *ast.Ident:
+			typ = e.Name
+			if optional && len(defs.required[e.Name]) > 0 {
+				pass.Report(analysis.Diagnostic{Pos: e.Pos(), Message: fmt.Sprintf(`attributes required by %s make the optional payload required and should be fixed manually`, e.Name)})
+			}
+		case *ast.FuncLit:
+			for _, stmt := range e.Body.List {
+				required := findCall([]ast.Stmt{stmt}, "Required")
+				if required == nil {
+					continue
+				}
+				if optional {
+					pass.Report(analysis.Diagnostic{Pos: required.Pos(), Message: `Required in OptionalPayload makes the payload required and should be fixed manually`})
+				} else if typ != "" && !hasAttributeDefinition(e.Body.List) {
+					pass.Report(analysis.Diagnostic{Pos: required.Pos(), Message: fmt.Sprintf(`Required in Payload should be checked since it is combined with the attributes required by %s`, typ)})
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// analyzePayloadAttributes adds the attributes declared in the statements of Params or Headers to the payload, and maps them by Param or Header in HTTP.
+// The origin is the DSL which the method inherits the statements from, or empty if they are declared by the method itself.
+func analyzePayloadAttributes(pass *analysis.Pass, ident *ast.Ident, list []ast.Stmt, group string, origin string, pathParams map[string]string, parent *[]ast.Stmt, parentHTTP *[]ast.Stmt) bool {
+	if len(list) == 0 {
+		return false
+	}
+	body := inlineBody(pass, parent, "Payload")
+	if body == nil {
+		return false
+	}
+	return moveAttributes(list, group, body, parentHTTP, pathParams, func(attr string) {
+		if origin != "" {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`payload attribute %q should be added for %s of %s`, attr, group, origin)})
+		}
+	})
+}
+
+// analyzePathParamAttributes adds the path parameters in names which no statement of Params in declared declares to the payload,
+// as attributes of String which goagen inferred them as.
+func analyzePathParamAttributes(pass *analysis.Pass, ident *ast.Ident, names []string, declared []ast.Stmt, pathParams map[string]string, parent *[]ast.Stmt, parentHTTP *[]ast.Stmt) bool {
+	var (
+		undeclared []string
+		list       []ast.Stmt
+	)
+	for _, name := range names {
+		if findParam(declared, name) != nil || hasString(undeclared, name) {
+			continue
+		}
+		undeclared = append(undeclared, name)
+		list = append(list, newCallStmt("Param", newStringLit(name), &ast.Ident{Name: "String"}))
+	}
+	if len(list) == 0 {
+		return false
+	}
+	body := inlineBody(pass, parent, "Payload")
+	if body == nil {
+		return false
+	}
+	return moveAttributes(list, "Params", body, parentHTTP, pathParams, func(attr string) {
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`payload attribute %q should be added for the path parameter`, attr)})
+	})
+}
+
+func analyzeParent(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
+	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Parent should be wrapped by HTTP`})
+	*parent = append(*parent, stmt)
+	return true
+}
+
+// analyzeParentParamConflicts reports the path parameters of the routes in expr which the parent also has.
+// params holds the names of the path parameters of the parent.
+func analyzeParentParamConflicts(pass *analysis.Pass, expr *ast.CallExpr, params map[string]string) {
+	for _, route := range expr.Args {
+		route, ok := route.(*ast.CallExpr)
+		if !ok || len(route.Args) == 0 {
+			continue
+		}
+		p, ok := constantString(pass, route.Args[0])
+		if !ok {
+			continue
+		}
+		for _, m := range regexpParam.FindAllStringSubmatch(p, -1) {
+			if hasValue(params, m[1]) {
+				pass.Report(analysis.Diagnostic{Pos: route.Args[0].Pos(), Message: fmt.Sprintf(`path parameter %q conflicts with the one of Parent and should be renamed manually`, m[1])})
+			}
+		}
+	}
+}
+
+func analyzeProduces(pass *analysis.Pass, stmt *ast.ExprStmt, parent *[]ast.Stmt) bool {
+	pass.Report(analysis.Diagnostic{Pos: stmt.Pos(), Message: `Produces should be wrapped by HTTP`})
+	*parent = append(*parent, stmt)
+	return true
+}
+
+func analyzeResource(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, ident *ast.Ident) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Resource should be replaced with Service`})
+	ident.Name = "Service"
+	r := &resource{}
+	if len(expr.Args) > 0 {
+		if name, ok := stringValue(expr.Args[0]); ok && defs.resources[name] != nil {
+			r = defs.resources[name]
+		}
+	}
+	for _, expr := range expr.Args {
+		expr, ok := expr.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		analyzeGenericDSL(pass.Report, expr)
+		inh := &inherited{
+			security:        defs.apiSecurity,
+			apiParams:       defs.apiParams,
+			resourceParams:  collectGroup(expr.Body.List, "Params"),
+			resourceHeaders: collectGroup(expr.Body.List, "Headers"),
+		}
+		if e := findCall(expr.Body.List, "DefaultMedia"); e != nil && len(e.Args) > 0 {
+			if len(e.Args) > 1 {
+				analyzeView(pass, defs, e)
+			}
+			inh.defaultMedia = e.Args
+		}
+		if security, ok := collectSecurity(pass, defs, expr.Body.List); ok {
+			inh.security = security
+		}
+		inh.noSecurity = findCall(expr.Body.List, "NoSecurity") != nil && len(defs.apiSecurity) > 0
+		if e := findCall(expr.Body.List, "Parent"); e != nil && len(e.Args) > 0 {
+			inh.parentParams, inh.parentPaths = collectParentParams(pass, defs, e.Args[0], nil)
+		}
+		inh.actions = r.actions
+		inh.pathParams = collectPathParams(defs.apiBasePath + r.basePath)
 		var (
+			changed          bool
 			listResource     []ast.Stmt
 			listResourceHTTP []ast.Stmt
 		)
@@ -557,25 +1464,34 @@ func analyzeResource(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident)
 			}
 			switch ident.Name {
 			case "Action":
-				analyzeAction(pass, stmt, expr, ident, &listResource)
+				analyzeAction(pass, defs, inh, stmt, expr, ident, &listResource)
 			case "BasePath":
 				analyzeBasePath(pass, stmt, expr, ident, &listResourceHTTP)
 			case "CanonicalActionName":
 				analyzeCanonicalActionName(pass, stmt, ident, &listResourceHTTP)
 			case "DefaultMedia":
-				analyzeDefaultMedia(pass, ident)
+				changed = analyzeDefaultMedia(pass, ident) || changed
+			case "Files":
+				changed = analyzeFiles(pass, defs, expr) || changed
+				listResource = append(listResource, stmt)
 			case "Headers":
-				analyzeHeaders(pass, stmt, &listResourceHTTP)
+				changed = analyzeHeaders(pass, stmt, "Resource") || changed
+			case "NoSecurity":
+				changed = analyzeNoSecurity(pass, ident, "Resource", len(defs.apiSecurity) > 0) || changed
+			case "Origin":
+				changed = analyzeOrigin(pass, expr, ident) || changed
+				listResource = append(listResource, stmt)
 			case "Params":
-				analyzeParams(pass, stmt, &listResourceHTTP)
+				changed = analyzeParams(pass, stmt, "Resource") || changed
 			case "Parent":
 				analyzeParent(pass, stmt, &listResourceHTTP)
 			case "Response":
-				analyzeResponse(pass, stmt, expr, &listResourceHTTP, &listResource)
+				analyzeResponse(pass, defs, stmt, expr, &listResourceHTTP, &listResource)
 			default:
 				listResource = append(listResource, stmt)
 			}
 		}
+		changed = analyzeServiceErrors(pass, ident, &listResource) || changed
 		if len(listResourceHTTP) > 0 {
 			listResource = append(listResource, &ast.ExprStmt{
 				X: &ast.CallExpr{
@@ -592,20 +1508,47 @@ func analyzeResource(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident)
 					},
 				},
 			})
+		}
+		if len(listResourceHTTP) > 0 || changed {
 			expr.Body.List = listResource
 		}
 	}
 	return true
 }
 
-func analyzeResponse(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr, parent *[]ast.Stmt, grandparent *[]ast.Stmt) bool {
+func analyzeResponse(pass *analysis.Pass, defs *definitions, stmt *ast.ExprStmt, expr *ast.CallExpr, parent *[]ast.Stmt, grandparent *[]ast.Stmt) bool {
 	pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `Response should be wrapped by HTTP`})
+	if len(expr.Args) == 0 {
+		*parent = append(*parent, stmt)
+		return true
+	}
 	var (
 		changed       bool
 		errorResponse bool
+		errorName     string
+		errorExpr     *ast.CallExpr
 		args          []ast.Expr
 	)
-	for _, e := range expr.Args {
+	name, ok := stringValue(expr.Args[0])
+	if !ok {
+		// A default response such as NotFound may be redefined in API.
+		if name, ok = constantString(pass, expr.Args[0]); ok && defs.responses[name] == nil {
+			ok = false
+		}
+	}
+	if ok {
+		if !expandResponse(pass, defs, expr, name) {
+			*parent = append(*parent, stmt)
+			return true
+		}
+		errorName = strcase.ToSnake(name)
+		changed = true
+	}
+	for i, e := range expr.Args {
+		// A status or ErrorMedia may be qualified by the package name such as design.OK.
+		if sel, ok := designIdent(pass, e); ok {
+			e = sel
+		}
 		switch t := e.(type) {
 		case *ast.Ident:
 			switch t.Name {
@@ -618,33 +1561,45 @@ func analyzeResponse(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr
 				"Gone", "LengthRequired", "PreconditionFailed", "RequestEntityTooLarge", "RequestURITooLong",
 				"UnsupportedMediaType", "RequestedRangeNotSatisfiable", "ExpectationFailed", "Teapot", "UnprocessableEntity",
 				"InternalServerError", "NotImplemented", "BadGateway", "ServiceUnavailable", "GatewayTimeout", "HTTPVersionNotSupported":
+				if errorName == "" {
+					errorName = strcase.ToSnake(t.Name)
+				}
 				errorResponse = true
-				errorName := fmt.Sprintf("%q", strcase.ToSnake(t.Name))
-				*grandparent = append(*grandparent, &ast.ExprStmt{
-					X: &ast.CallExpr{
-						Fun: &ast.Ident{
-							Name: "Error",
-						},
-						Args: []ast.Expr{
-							&ast.BasicLit{
-								Kind:  token.STRING,
-								Value: errorName,
-							},
-						},
-					},
-				})
-				args = append(args, &ast.Ident{
-					Name: errorName,
-				})
+				errorExpr = analyzeError(errorName, grandparent)
+				lit := newStringLit(errorName)
+				lit.ValuePos = t.Pos()
+				args = append(args, lit)
 				fallthrough
 			case "Continue", "SwitchingProtocols",
 				"OK", "Created", "Accepted", "NonAuthoritativeInfo", "NoContent", "ResetContent", "PartialContent",
 				"MultipleChoices", "MovedPermanently", "Found", "SeeOther", "NotModified", "UseProxy", "TemporaryRedirect":
 				changed = analyzeHTTPStatusConstant(pass, t) || changed
+			default:
+				if i > 0 && errorExpr != nil && analyzeErrorMedia(pass, t, errorExpr) {
+					changed = true
+					continue
+				}
+				if i > 0 && !errorResponse && analyzeResponseMedia(pass, t, grandparent) {
+					changed = true
+					continue
+				}
+			}
+			args = append(args, t)
+		case *ast.CallExpr, *ast.SelectorExpr:
+			if i > 0 && errorExpr != nil && analyzeErrorMedia(pass, t, errorExpr) {
+				changed = true
+				continue
+			}
+			if i > 0 && !errorResponse && analyzeResponseMedia(pass, t, grandparent) {
+				changed = true
+				continue
 			}
 			args = append(args, t)
 		case *ast.FuncLit:
-			var list []ast.Stmt
+			var (
+				list    []ast.Stmt
+				headers []ast.Stmt
+			)
 			for _, s := range t.Body.List {
 				s, ok := s.(*ast.ExprStmt)
 				if !ok {
@@ -659,15 +1614,29 @@ func analyzeResponse(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr
 					continue
 				}
 				switch i.Name {
+				case "Headers":
+					if errorResponse {
+						pass.Report(analysis.Diagnostic{Pos: i.Pos(), Message: `Headers in an error response should be converted manually`})
+						list = append(list, s)
+						continue
+					}
+					pass.Report(analysis.Diagnostic{Pos: i.Pos(), Message: `Headers in Response should be replaced with Result attributes and Header mappings`})
+					headers = append(headers, collectGroup([]ast.Stmt{s}, "Headers")...)
+					changed = true
 				case "Media":
-					changed = analyzeMedia(pass, s, i, grandparent, errorResponse) || changed
+					changed = analyzeMedia(pass, defs, s, i, grandparent, errorResponse, errorExpr) || changed
 				case "Status":
 					changed = analyzeStatus(pass, s, i, &list) || changed
 				default:
 					list = append(list, s)
 				}
 			}
-			if len(list) != len(t.Body.List) {
+			if len(headers) > 0 {
+				if body := inlineBody(pass, grandparent, "Result"); body != nil {
+					moveAttributes(headers, "Headers", body, &list, nil, func(string) {})
+				}
+			}
+			if len(headers) > 0 || len(list) != len(t.Body.List) {
 				t.Body.List = list
 			}
 			if len(t.Body.List) > 0 {
@@ -684,7 +1653,91 @@ func analyzeResponse(pass *analysis.Pass, stmt *ast.ExprStmt, expr *ast.CallExpr
 	return true
 }
 
-func analyzeRouting(pass *analysis.Pass, expr *ast.CallExpr, parent *[]ast.Stmt) bool {
+// analyzeResponseTemplate removes a named response declared in API, which is expanded where it is used,
+// and reports whether it is removed or not.
+func analyzeResponseTemplate(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, ident *ast.Ident) bool {
+	if len(expr.Args) > 0 {
+		if name, ok := constantString(pass, expr.Args[0]); ok && defs.responses[name] != nil {
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s should be removed since it is expanded in each Response using it`, ident.Name)})
+			return true
+		}
+	}
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s cannot be resolved and should be converted manually`, ident.Name)})
+	return false
+}
+
+// analyzeResponseMedia moves media of a response to Result of the method, and reports whether it is moved or not.
+func analyzeResponseMedia(pass *analysis.Pass, media ast.Expr, parent *[]ast.Stmt) bool {
+	pass.Report(analysis.Diagnostic{Pos: media.Pos(), Message: `media of a non-error response should be replaced with Result`})
+	exists, conflicted := analyzeResultConflict(pass, media, *parent)
+	if !exists {
+		*parent = append(*parent, &ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.Ident{
+					Name: "Result",
+				},
+				Args: []ast.Expr{
+					media,
+				},
+			},
+		})
+	}
+	return !conflicted
+}
+
+// headerResult returns the DSL of Result if it only holds the attributes for the headers of the responses.
+func headerResult(expr *ast.CallExpr) *ast.FuncLit {
+	if len(expr.Args) == 0 {
+		return nil
+	}
+	dsl, ok := expr.Args[0].(*ast.FuncLit)
+	if !ok || findCall(dsl.Body.List, "Extend") != nil || findCall(dsl.Body.List, "Reference") != nil {
+		return nil
+	}
+	return dsl
+}
+
+// analyzeResultConflict reports whether the method in list already has Result, and whether it conflicts with media or not.
+func analyzeResultConflict(pass *analysis.Pass, media ast.Expr, list []ast.Stmt) (bool, bool) {
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); !ok || ident.Name != "Result" {
+			continue
+		}
+		if dsl := headerResult(expr); dsl != nil {
+			dsl.Body.List = append([]ast.Stmt{newCallStmt("Extend", media)}, dsl.Body.List...)
+			return true, false
+		}
+		result := expr.Args[0]
+		if e, ok := result.(*ast.FuncLit); ok {
+			result = nil
+			if len(e.Body.List) > 0 {
+				if s, ok := e.Body.List[0].(*ast.ExprStmt); ok {
+					if e, ok := s.X.(*ast.CallExpr); ok && len(e.Args) > 0 {
+						if i, ok := e.Fun.(*ast.Ident); ok && i.Name == "Extend" {
+							result = e.Args[0]
+						}
+					}
+				}
+			}
+		}
+		if result == nil || !bytes.Equal(formatNode(pass.Fset, result), formatNode(pass.Fset, media)) {
+			pass.Report(analysis.Diagnostic{Pos: media.Pos(), Message: `media of the response conflicts with Result of another response and should be fixed manually`})
+			return true, true
+		}
+		return true, false
+	}
+	return false, false
+}
+
+func analyzeRouting(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, parent *[]ast.Stmt) bool {
 	pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `Routing should be replaced with HTTP`})
 	for _, e := range expr.Args {
 		e, ok := e.(*ast.CallExpr)
@@ -697,30 +1750,1340 @@ func analyzeRouting(pass *analysis.Pass, expr *ast.CallExpr, parent *[]ast.Stmt)
 		}
 		switch ident.Name {
 		case "GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH":
-			analyzeHTTPRoutingDSL(pass, e)
 			*parent = append(*parent, &ast.ExprStmt{X: e})
+			analyzeHTTPRoutingDSL(pass, defs, e, ident, parent)
 		}
 	}
 	return true
 }
 
-func analyzeStatus(pass *analysis.Pass, stmt *ast.ExprStmt, ident *ast.Ident, parent *[]ast.Stmt) bool {
-	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Status should be replaced with Code`})
-	ident.Name = "Code"
-	*parent = append(*parent, stmt)
-	return true
+func analyzeSecuredPayload(pass *analysis.Pass, ident *ast.Ident, security []*securityScheme, parent *[]ast.Stmt, parentHTTP *[]ast.Stmt) bool {
+	var changed bool
+	for _, scheme := range security {
+		var (
+			attrs   []string
+			stmts   []ast.Stmt
+			mapping string
+		)
+		switch scheme.dsl {
+		case "APIKeySecurity":
+			attrs = []string{"key"}
+			stmts = []ast.Stmt{newCallStmt("APIKey", newStringLit(scheme.name), newStringLit("key"), &ast.Ident{Name: "String"})}
+		case "BasicAuthSecurity":
+			attrs = []string{"username", "password"}
+			stmts = []ast.Stmt{
+				newCallStmt("Username", newStringLit("username"), &ast.Ident{Name: "String"}),
+				newCallStmt("Password", newStringLit("password"), &ast.Ident{Name: "String"}),
+			}
+		case "JWTSecurity":
+			attrs = []string{"token"}
+			stmts = []ast.Stmt{newCallStmt("Token", newStringLit("token"), &ast.Ident{Name: "String"})}
+		case "OAuth2Security":
+			attrs = []string{"access_token"}
+			stmts = []ast.Stmt{newCallStmt("AccessToken", newStringLit("access_token"), &ast.Ident{Name: "String"})}
+		default:
+			continue
+		}
+		switch scheme.in {
+		case "header":
+			mapping = "Header"
+		case "query":
+			mapping = "Param"
+		}
+		body := inlineBody(pass, parent, "Payload")
+		if body == nil {
+			return changed
+		}
+		for i, attr := range attrs {
+			if hasAttribute(body.List, attr) {
+				continue
+			}
+			pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`payload attribute %q should be added for security scheme %q`, attr, scheme.name)})
+			body.List = append(body.List, stmts[i])
+			if mapping != "" {
+				name := attr
+				if scheme.key != attr {
+					name += ":" + scheme.key
+				}
+				*parentHTTP = append(*parentHTTP, newCallStmt(mapping, newStringLit(name)))
+			}
+			changed = true
+		}
+	}
+	return changed
 }
 
-func analyzeType(pass *analysis.Pass, expr *ast.CallExpr) bool {
+func analyzeSecurityScheme(pass *analysis.Pass, expr *ast.CallExpr, ident *ast.Ident) bool {
 	var changed bool
-	for _, expr := range expr.Args {
-		expr, ok := expr.(*ast.FuncLit)
+	for _, e := range expr.Args {
+		e, ok := e.(*ast.FuncLit)
 		if !ok {
 			continue
 		}
-		changed = analyzeGenericDSL(pass, expr) || changed
-	}
-	return changed
+		var list []ast.Stmt
+		for _, s := range e.Body.List {
+			list = append(list, s)
+			s, ok := s.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			e, ok := s.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			i, ok := e.Fun.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch ident.Name {
+			case "APIKeySecurity", "JWTSecurity":
+				switch i.Name {
+				case "Header", "Query":
+					pass.Report(analysis.Diagnostic{Pos: i.Pos(), Message: fmt.Sprintf(`%s in %s should be moved to HTTP of secured methods`, i.Name, ident.Name)})
+					list = list[:len(list)-1]
+					changed = true
+				case "TokenURL":
+					pass.Report(analysis.Diagnostic{Pos: i.Pos(), Message: fmt.Sprintf(`TokenURL in %s should be removed`, ident.Name)})
+					list = list[:len(list)-1]
+					changed = true
+				}
+			case "OAuth2Security":
+				switch i.Name {
+				case "AccessCodeFlow", "ApplicationFlow", "ImplicitFlow", "PasswordFlow":
+					changed = analyzeOAuth2Flow(pass, e, i) || changed
+				}
+			}
+		}
+		if changed {
+			e.Body.List = list
+		}
+	}
+	return changed
+}
+
+// analyzeServer replaces Host and Scheme of API with Server named name, which serves every service.
+func analyzeServer(pass *analysis.Pass, defs *definitions, name string, hosts []*ast.CallExpr, schemes []*ast.CallExpr, parent *[]ast.Stmt) bool {
+	if len(hosts) == 0 && len(schemes) == 0 {
+		return false
+	}
+	var host ast.Expr = newStringLit("localhost")
+	for _, expr := range hosts {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `Host should be replaced with URI of Host in Server`})
+		if len(expr.Args) > 0 {
+			host = expr.Args[0]
+		}
+	}
+	var names []string
+	for _, expr := range schemes {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `Scheme should be replaced with the scheme of URI of Host in Server`})
+		for _, arg := range expr.Args {
+			scheme, ok := stringValue(arg)
+			if !ok {
+				pass.Report(analysis.Diagnostic{Pos: arg.Pos(), Message: `scheme should be converted to URI manually`})
+				continue
+			}
+			names = append(names, scheme)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"http"}
+	}
+	var list []ast.Stmt
+	for _, scheme := range names {
+		var uri ast.Expr
+		if v, ok := stringValue(host); ok {
+			uri = newStringLit(scheme + "://" + v)
+		} else {
+			uri = &ast.BinaryExpr{X: newStringLit(scheme + "://"), Op: token.ADD, Y: host}
+		}
+		list = append(list, newCallStmt("URI", uri))
+	}
+	var services []ast.Expr
+	for _, service := range defs.services {
+		services = append(services, newStringLit(service))
+	}
+	dsl := &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: &ast.BlockStmt{},
+	}
+	if len(services) > 0 {
+		dsl.Body.List = append(dsl.Body.List, newCallStmt("Services", services...))
+	}
+	dsl.Body.List = append(dsl.Body.List, newCallStmt("Host", newStringLit("production"), &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: &ast.BlockStmt{List: list},
+	}))
+	*parent = append(*parent, newCallStmt("Server", newStringLit(name), dsl))
+	return true
+}
+
+// analyzeServiceErrors moves Error which every method in parent has to the service.
+func analyzeServiceErrors(pass *analysis.Pass, ident *ast.Ident, parent *[]ast.Stmt) bool {
+	var (
+		first   int
+		methods []*ast.BlockStmt
+	)
+	for i, stmt := range *parent {
+		if e := findCall([]ast.Stmt{stmt}, "Method"); e != nil {
+			for _, e := range e.Args {
+				if e, ok := e.(*ast.FuncLit); ok {
+					if len(methods) == 0 {
+						first = i
+					}
+					methods = append(methods, e.Body)
+				}
+			}
+		}
+	}
+	if len(methods) < 2 {
+		return false
+	}
+	var errors []ast.Stmt
+	for _, stmt := range methods[0].List {
+		if findCall([]ast.Stmt{stmt}, "Error") == nil {
+			continue
+		}
+		b := formatNode(pass.Fset, stmt)
+		shared := true
+		for _, m := range methods[1:] {
+			if indexStmt(pass.Fset, m.List, b) < 0 {
+				shared = false
+				break
+			}
+		}
+		if !shared {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: fmt.Sprintf(`%s should be moved to Service since every Method has it`, b)})
+		for _, m := range methods {
+			i := indexStmt(pass.Fset, m.List, b)
+			m.List = append(m.List[:i:i], m.List[i+1:]...)
+		}
+		errors = append(errors, stmt)
+	}
+	if len(errors) == 0 {
+		return false
+	}
+	list := append([]ast.Stmt{}, (*parent)[:first]...)
+	list = append(list, errors...)
+	*parent = append(list, (*parent)[first:]...)
+	return true
+}
+
+func analyzeStatus(pass *analysis.Pass, stmt *ast.ExprStmt, ident *ast.Ident, parent *[]ast.Stmt) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Status should be replaced with Code`})
+	ident.Name = "Code"
+	*parent = append(*parent, stmt)
+	return true
+}
+
+// analyzeTrait removes Trait declared in API, which is inlined in each UseTrait.
+func analyzeTrait(pass *analysis.Pass, ident *ast.Ident) bool {
+	pass.Report(analysis.Diagnostic{Pos: ident.Pos(), Message: `Trait should be removed since it is inlined in each UseTrait`})
+	return true
+}
+
+func analyzeType(pass *analysis.Pass, expr *ast.CallExpr) bool {
+	var changed bool
+	for _, expr := range expr.Args {
+		expr, ok := expr.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		changed = analyzeGenericDSL(pass.Report, expr) || changed
+	}
+	return changed
+}
+
+// analyzeUseTrait replaces UseTrait in the DSLs of node with the DSLs of the traits.
+// expanding holds the names of the traits being inlined to prevent a recursion.
+func analyzeUseTrait(pass *analysis.Pass, defs *definitions, node ast.Node, expanding []string) bool {
+	var changed bool
+	ast.Inspect(node, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		var list []ast.Stmt
+		for _, stmt := range block.List {
+			expr := findCall([]ast.Stmt{stmt}, "UseTrait")
+			if expr == nil {
+				changed = analyzeUseTrait(pass, defs, stmt, expanding) || changed
+				list = append(list, stmt)
+				continue
+			}
+			var args []ast.Expr
+			for _, arg := range expr.Args {
+				name, ok := stringValue(arg)
+				if !ok {
+					args = append(args, arg)
+					continue
+				}
+				trait, ok := defs.traits[name]
+				if !ok || hasString(expanding, name) {
+					pass.Report(analysis.Diagnostic{Pos: arg.Pos(), Message: fmt.Sprintf(`trait %q cannot be resolved`, name)})
+					args = append(args, arg)
+					continue
+				}
+				pass.Report(analysis.Diagnostic{Pos: arg.Pos(), Message: fmt.Sprintf(`UseTrait should be replaced with the DSL of trait %q`, name)})
+				body := &ast.BlockStmt{}
+				for _, stmt := range trait.List {
+					stmt = cloneStmt(stmt)
+					substituteParams(stmt, nil, arg.Pos())
+					body.List = append(body.List, stmt)
+				}
+				analyzeUseTrait(pass, defs, body, append(expanding[:len(expanding):len(expanding)], name))
+				list = append(list, body.List...)
+				changed = true
+			}
+			if len(args) > 0 {
+				expr.Args = args
+				list = append(list, stmt)
+			}
+		}
+		block.List = list
+		return false
+	})
+	return changed
+}
+
+func cloneExpr(expr ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return &ast.BasicLit{Kind: expr.Kind, Value: expr.Value}
+	case *ast.BinaryExpr:
+		return &ast.BinaryExpr{X: cloneExpr(expr.X), Op: expr.Op, Y: cloneExpr(expr.Y)}
+	case *ast.CallExpr:
+		args := make([]ast.Expr, len(expr.Args))
+		for i, e := range expr.Args {
+			args[i] = cloneExpr(e)
+		}
+		return &ast.CallExpr{Fun: cloneExpr(expr.Fun), Args: args}
+	case *ast.FuncLit:
+		list := make([]ast.Stmt, len(expr.Body.List))
+		for i, s := range expr.Body.List {
+			list[i] = cloneStmt(s)
+		}
+		return &ast.FuncLit{Type: &ast.FuncType{}, Body: &ast.BlockStmt{List: list}}
+	case *ast.Ident:
+		return &ast.Ident{Name: expr.Name}
+	case *ast.ParenExpr:
+		return &ast.ParenExpr{X: cloneExpr(expr.X)}
+	case *ast.SelectorExpr:
+		return &ast.SelectorExpr{X: cloneExpr(expr.X), Sel: &ast.Ident{Name: expr.Sel.Name}}
+	case *ast.UnaryExpr:
+		return &ast.UnaryExpr{Op: expr.Op, X: cloneExpr(expr.X)}
+	}
+	return expr
+}
+
+func cloneStmt(stmt ast.Stmt) ast.Stmt {
+	if stmt, ok := stmt.(*ast.ExprStmt); ok {
+		return &ast.ExprStmt{X: cloneExpr(stmt.X)}
+	}
+	return stmt
+}
+
+// collectAttributeNames returns the names of the attributes declared in list.
+func collectAttributeNames(list []ast.Stmt) []string {
+	var names []string
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); !ok || (ident.Name != "Attribute" && ident.Name != "Member") {
+			continue
+		}
+		if name, ok := stringValue(expr.Args[0]); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func collectDefinitions(pass *analysis.Pass) *definitions {
+	defs := &definitions{
+		schemes:    make(map[string]*securityScheme),
+		mediaTypes: make(map[string]*mediaType),
+		responses:  make(map[string]*responseTemplate),
+		traits:     make(map[string]*ast.BlockStmt),
+		resources:  make(map[string]*resource),
+		required:   make(map[string][]string),
+		expanded:   make(map[*ast.CallExpr]bool),
+	}
+	apis := findVariableCalls(pass, "API")
+	// Traits are expanded before collecting the other definitions since they may declare Params or Routing.
+	for _, expr := range apis {
+		for _, e := range expr.Args {
+			if e, ok := e.(*ast.FuncLit); ok {
+				collectTraits(defs, e.Body.List)
+			}
+		}
+	}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, expr := range spec.Values {
+					expr, ok := expr.(*ast.CallExpr)
+					if !ok {
+						continue
+					}
+					ident, ok := expr.Fun.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if ident.Name != "API" {
+						defs.expanded[expr] = analyzeUseTrait(pass, defs, expr, nil)
+					}
+					switch ident.Name {
+					case "APIKeySecurity", "BasicAuthSecurity", "JWTSecurity", "OAuth2Security":
+						if i < len(spec.Names) {
+							defs.schemes[spec.Names[i].Name] = collectSecurityScheme(expr, ident)
+						}
+					case "MediaType":
+						if i < len(spec.Names) {
+							defs.mediaTypes[spec.Names[i].Name] = collectMediaType(expr)
+						}
+					case "Type":
+						if i < len(spec.Names) {
+							defs.required[spec.Names[i].Name] = collectRequired(expr)
+						}
+					case "Resource":
+						if len(expr.Args) > 0 {
+							if name, ok := stringValue(expr.Args[0]); ok {
+								defs.services = append(defs.services, name)
+								defs.resources[name] = collectResource(pass, expr)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, expr := range apis {
+		for _, e := range expr.Args {
+			if e, ok := e.(*ast.FuncLit); ok {
+				defs.apiSecurity, _ = collectSecurity(pass, defs, e.Body.List)
+				defs.apiParams = collectGroup(e.Body.List, "Params")
+				if c := findCall(e.Body.List, "BasePath"); c != nil && len(c.Args) > 0 {
+					defs.apiBasePath, _ = constantString(pass, c.Args[0])
+				}
+				collectResponseTemplates(pass, defs, e.Body.List)
+			}
+		}
+	}
+	return defs
+}
+
+// findVariableCalls returns the calls of the DSL named name which are assigned to the variables.
+func findVariableCalls(pass *analysis.Pass, name string) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, expr := range spec.Values {
+					if expr, ok := expr.(*ast.CallExpr); ok {
+						if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == name {
+							calls = append(calls, expr)
+						}
+					}
+				}
+			}
+		}
+	}
+	return calls
+}
+
+// collectPathParams returns the names of the path parameters in paths.
+func collectPathParams(paths ...string) []string {
+	var names []string
+	for _, p := range paths {
+		for _, m := range regexpParam.FindAllStringSubmatch(p, -1) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// collectGroup returns the statements of the group DSL such as Params or Headers in list.
+func collectGroup(list []ast.Stmt, name string) []ast.Stmt {
+	var group []ast.Stmt
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); !ok || ident.Name != name {
+			continue
+		}
+		for _, e := range expr.Args {
+			if e, ok := e.(*ast.FuncLit); ok {
+				group = append(group, e.Body.List...)
+			}
+		}
+	}
+	return group
+}
+
+// collectRequired returns the names of the attributes required by the DSL of expr.
+func collectRequired(expr *ast.CallExpr) []string {
+	var names []string
+	for _, e := range expr.Args {
+		e, ok := e.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		for _, stmt := range e.Body.List {
+			if e := findCall([]ast.Stmt{stmt}, "Required"); e != nil {
+				for _, e := range e.Args {
+					if name, ok := stringValue(e); ok {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func collectMediaType(expr *ast.CallExpr) *mediaType {
+	mt := &mediaType{views: []string{"default"}}
+	for _, e := range expr.Args {
+		switch e := e.(type) {
+		case *ast.BasicLit:
+			mt.identifier, _ = stringValue(e)
+		case *ast.FuncLit:
+			mt.attributes = append(mt.attributes, collectAttributeNames(e.Body.List)...)
+			for _, s := range e.Body.List {
+				s, ok := s.(*ast.ExprStmt)
+				if !ok {
+					continue
+				}
+				e, ok := s.X.(*ast.CallExpr)
+				if !ok || len(e.Args) == 0 {
+					continue
+				}
+				i, ok := e.Fun.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch i.Name {
+				case "Attributes":
+					for _, e := range e.Args {
+						if e, ok := e.(*ast.FuncLit); ok {
+							mt.attributes = append(mt.attributes, collectAttributeNames(e.Body.List)...)
+						}
+					}
+				case "View":
+					if name, ok := stringValue(e.Args[0]); ok && !hasString(mt.views, name) {
+						mt.views = append(mt.views, name)
+					}
+				}
+			}
+		}
+	}
+	return mt
+}
+
+// collectParentParams returns Params for the path parameters of the canonical action of the parent resource named by expr,
+// and the names of the path parameters keyed by the names of the attributes.
+// The attributes are named after the parent as goagen renames the path parameters, e.g. "id" of "account" to "accountID".
+// visited holds the names of the resources visited to prevent a recursion.
+func collectParentParams(pass *analysis.Pass, defs *definitions, expr ast.Expr, visited []string) ([]ast.Stmt, map[string]string) {
+	name, ok := stringValue(expr)
+	if !ok {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: `parent should be resolved manually`})
+		return nil, nil
+	}
+	r, ok := defs.resources[name]
+	if !ok || hasString(visited, name) {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: fmt.Sprintf(`parent %q cannot be resolved`, name)})
+		return nil, nil
+	}
+	a, ok := r.actions[r.canonical]
+	if !ok || len(a.routes) == 0 {
+		pass.Report(analysis.Diagnostic{Pos: expr.Pos(), Message: fmt.Sprintf(`canonical action %q of parent %q cannot be resolved`, r.canonical, name)})
+		return nil, nil
+	}
+	var params []ast.Stmt
+	paths := make(map[string]string)
+	if r.parent != "" && !strings.HasPrefix(a.routes[0], "//") {
+		var p map[string]string
+		params, p = collectParentParams(pass, defs, &ast.BasicLit{ValuePos: expr.Pos(), Kind: token.STRING, Value: strconv.Quote(r.parent)}, append(visited[:len(visited):len(visited)], name))
+		for attr, path := range p {
+			paths[attr] = path
+		}
+	}
+	var names []string
+	for _, m := range regexpParam.FindAllStringSubmatch(r.basePath+a.routes[0], -1) {
+		path := m[1]
+		attr := path
+		if !strings.HasPrefix(path, strcase.ToLowerCamel(name)) {
+			attr = strcase.ToLowerCamel(name) + capitalize(path)
+		}
+		names = append(names, attr)
+		paths[attr] = path
+		stmt := findParam(a.params, path)
+		if stmt == nil {
+			stmt = findParam(r.params, path)
+		}
+		if stmt == nil {
+			stmt = newCallStmt("Param", newStringLit(path), &ast.Ident{Name: "String"})
+		}
+		stmt = cloneStmt(stmt)
+		if e := findCall([]ast.Stmt{stmt}, "Param"); e != nil {
+			e.Args[0] = newStringLit(attr)
+		}
+		params = append(params, stmt)
+	}
+	if len(names) > 0 {
+		var args []ast.Expr
+		for _, name := range names {
+			args = append(args, newStringLit(name))
+		}
+		params = append(params, newCallStmt("Required", args...))
+	}
+	return params, paths
+}
+
+// collectResource collects the routing of the resource declared by expr.
+func collectResource(pass *analysis.Pass, expr *ast.CallExpr) *resource {
+	r := &resource{
+		canonical: "show",
+		actions:   make(map[string]*action),
+	}
+	for _, e := range expr.Args {
+		e, ok := e.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		if c := findCall(e.Body.List, "BasePath"); c != nil && len(c.Args) > 0 {
+			r.basePath, _ = constantString(pass, c.Args[0])
+		}
+		if c := findCall(e.Body.List, "Parent"); c != nil && len(c.Args) > 0 {
+			r.parent, _ = stringValue(c.Args[0])
+		}
+		if c := findCall(e.Body.List, "CanonicalActionName"); c != nil && len(c.Args) > 0 {
+			r.canonical, _ = stringValue(c.Args[0])
+		}
+		r.params = collectGroup(e.Body.List, "Params")
+		for _, stmt := range e.Body.List {
+			c := findCall([]ast.Stmt{stmt}, "Action")
+			if c == nil || len(c.Args) < 2 {
+				continue
+			}
+			name, ok := stringValue(c.Args[0])
+			if !ok {
+				continue
+			}
+			a := &action{}
+			if dsl, ok := c.Args[1].(*ast.FuncLit); ok {
+				a.params = collectGroup(dsl.Body.List, "Params")
+				if routing := findCall(dsl.Body.List, "Routing"); routing != nil {
+					for _, route := range routing.Args {
+						if route, ok := route.(*ast.CallExpr); ok && len(route.Args) > 0 {
+							// The routes expanded from a trait are not type-checked.
+							p, ok := stringValue(route.Args[0])
+							if !ok {
+								p, ok = constantString(pass, route.Args[0])
+							}
+							if ok {
+								a.routes = append(a.routes, p)
+							}
+						}
+					}
+				}
+			}
+			r.actions[name] = a
+		}
+	}
+	return r
+}
+
+// collectResponseTemplates collects the named responses declared by Response or ResponseTemplate in list.
+// The names may be given by string literals or by the constants of the default responses such as NotFound.
+func collectResponseTemplates(pass *analysis.Pass, defs *definitions, list []ast.Stmt) {
+	for _, name := range []string{"Response", "ResponseTemplate"} {
+		for _, stmt := range list {
+			expr := findCall([]ast.Stmt{stmt}, name)
+			if expr == nil || len(expr.Args) < 2 {
+				continue
+			}
+			n, ok := constantString(pass, expr.Args[0])
+			if !ok {
+				continue
+			}
+			dsl, ok := expr.Args[len(expr.Args)-1].(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			tmpl := &responseTemplate{body: dsl.Body}
+			for _, field := range dsl.Type.Params.List {
+				for _, ident := range field.Names {
+					tmpl.params = append(tmpl.params, ident.Name)
+				}
+			}
+			defs.responses[n] = tmpl
+		}
+	}
+}
+
+// collectTraits collects the bodies of Trait declared in list.
+func collectTraits(defs *definitions, list []ast.Stmt) {
+	for _, stmt := range list {
+		expr := findCall([]ast.Stmt{stmt}, "Trait")
+		if expr == nil || len(expr.Args) < 2 {
+			continue
+		}
+		name, ok := stringValue(expr.Args[0])
+		if !ok {
+			continue
+		}
+		if dsl, ok := expr.Args[1].(*ast.FuncLit); ok {
+			defs.traits[name] = dsl.Body
+		}
+	}
+}
+
+// collectSecurity returns the security schemes required by Security in list, and whether Security or NoSecurity is declared or not.
+func collectSecurity(pass *analysis.Pass, defs *definitions, list []ast.Stmt) ([]*securityScheme, bool) {
+	var (
+		security   []*securityScheme
+		declared   bool
+		noSecurity bool
+	)
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := expr.Fun.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch ident.Name {
+		case "NoSecurity":
+			declared, noSecurity = true, true
+			continue
+		case "Security":
+			declared = true
+		default:
+			continue
+		}
+		if len(expr.Args) == 0 {
+			continue
+		}
+		scheme := lookupSecurityScheme(defs, expr.Args[0])
+		if scheme == nil {
+			pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: `security scheme cannot be resolved`})
+			continue
+		}
+		for _, e := range expr.Args[1:] {
+			e, ok := e.(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			for _, s := range e.Body.List {
+				s, ok := s.(*ast.ExprStmt)
+				if !ok {
+					continue
+				}
+				e, ok := s.X.(*ast.CallExpr)
+				if !ok || len(e.Args) == 0 {
+					continue
+				}
+				if i, ok := e.Fun.(*ast.Ident); !ok || i.Name != "Scope" {
+					continue
+				}
+				if name, ok := stringValue(e.Args[0]); ok && !hasString(scheme.scopes, name) {
+					pass.Report(analysis.Diagnostic{Pos: e.Args[0].Pos(), Message: fmt.Sprintf(`scope %q is not declared in security scheme %q`, name, scheme.name)})
+				}
+			}
+		}
+		security = append(security, scheme)
+	}
+	if noSecurity {
+		return nil, true
+	}
+	return security, declared
+}
+
+func collectSecurityScheme(expr *ast.CallExpr, ident *ast.Ident) *securityScheme {
+	scheme := &securityScheme{dsl: ident.Name}
+	switch ident.Name {
+	case "JWTSecurity", "OAuth2Security":
+		scheme.in, scheme.key = "header", "Authorization"
+	}
+	for _, e := range expr.Args {
+		switch e := e.(type) {
+		case *ast.BasicLit:
+			scheme.name, _ = stringValue(e)
+		case *ast.FuncLit:
+			for _, s := range e.Body.List {
+				s, ok := s.(*ast.ExprStmt)
+				if !ok {
+					continue
+				}
+				e, ok := s.X.(*ast.CallExpr)
+				if !ok || len(e.Args) == 0 {
+					continue
+				}
+				i, ok := e.Fun.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				v, ok := stringValue(e.Args[0])
+				if !ok {
+					continue
+				}
+				switch i.Name {
+				case "Header":
+					scheme.in, scheme.key = "header", v
+				case "Query":
+					scheme.in, scheme.key = "query", v
+				case "Scope":
+					scheme.scopes = append(scheme.scopes, v)
+				}
+			}
+		}
+	}
+	return scheme
+}
+
+// inlineBody returns the body of the inline Payload or Result in list.
+// The DSL with a user type is replaced with an inline one extending or referring the type, and an empty one is added if the method has no such DSL.
+func inlineBody(pass *analysis.Pass, list *[]ast.Stmt, name string) *ast.BlockStmt {
+	for _, stmt := range *list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		ident, ok := expr.Fun.(*ast.Ident)
+		if !ok || ident.Name != name {
+			continue
+		}
+		if e, ok := expr.Args[0].(*ast.FuncLit); ok {
+			return e.Body
+		}
+		switch expr.Args[0].(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+		default:
+			pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`%s with a type should be converted to an inline %s manually`, name, strings.ToLower(name))})
+			return nil
+		}
+		dslName := "Extend"
+		dsl := &ast.FuncLit{
+			Type: &ast.FuncType{},
+			Body: &ast.BlockStmt{},
+		}
+		if len(expr.Args) > 1 {
+			if e, ok := expr.Args[1].(*ast.FuncLit); ok {
+				if hasAttributeDefinition(e.Body.List) {
+					dslName = "Reference"
+				}
+				dsl = e
+			}
+		}
+		pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`%s with a type should be replaced with an inline %s using %s`, name, strings.ToLower(name), dslName)})
+		dsl.Body.List = append([]ast.Stmt{newCallStmt(dslName, expr.Args[0])}, dsl.Body.List...)
+		expr.Args = []ast.Expr{dsl}
+		return dsl.Body
+	}
+	body := &ast.BlockStmt{}
+	*list = append(*list, newCallStmt(name, &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: body,
+	}))
+	return body
+}
+
+// hasAttribute reports whether list declares the attribute with name.
+func hasAttribute(list []ast.Stmt, name string) bool {
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := expr.Fun.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		var e ast.Expr
+		switch ident.Name {
+		case "APIKey":
+			if len(expr.Args) > 1 {
+				e = expr.Args[1]
+			}
+		case "AccessToken", "Attribute", "Member", "Password", "Token", "Username":
+			if len(expr.Args) > 0 {
+				e = expr.Args[0]
+			}
+		}
+		if v, ok := stringValue(e); ok && v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAttributeDefinition reports whether list declares any attribute.
+func hasAttributeDefinition(list []ast.Stmt) bool {
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := expr.Fun.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch ident.Name {
+		case "Attribute", "Member":
+			return true
+		}
+	}
+	return false
+}
+
+// expandResponse replaces the name of a custom response in expr with the status constant and the DSL of its definition.
+func expandResponse(pass *analysis.Pass, defs *definitions, expr *ast.CallExpr, name string) bool {
+	var (
+		body *ast.BlockStmt
+		dsl  *ast.FuncLit
+		args []ast.Expr
+	)
+	for _, e := range expr.Args[1:] {
+		if e, ok := e.(*ast.FuncLit); ok {
+			dsl = e
+			continue
+		}
+		args = append(args, e)
+	}
+	if tmpl, ok := defs.responses[name]; ok {
+		pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`response %q should be expanded with its definition`, name)})
+		values := make(map[string]ast.Expr)
+		for i, param := range tmpl.params {
+			if i < len(args) {
+				values[param] = args[i]
+			}
+		}
+		body = &ast.BlockStmt{}
+		for _, stmt := range tmpl.body.List {
+			stmt = cloneStmt(stmt)
+			substituteParams(stmt, values, expr.Args[0].Pos())
+			body.List = append(body.List, stmt)
+		}
+		if dsl != nil {
+			body.List = append(body.List, dsl.Body.List...)
+		}
+	} else if dsl != nil {
+		pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`response %q should be expanded with its definition`, name)})
+		body = dsl.Body
+	} else {
+		for _, n := range statusNames {
+			if n == name {
+				expr.Args[0] = &ast.Ident{NamePos: expr.Args[0].Pos(), Name: name}
+				return true
+			}
+		}
+		pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`response %q cannot be resolved`, name)})
+		return false
+	}
+	var (
+		status string
+		list   []ast.Stmt
+	)
+	for _, stmt := range body.List {
+		if e := findCall([]ast.Stmt{stmt}, "Status"); e != nil && len(e.Args) == 1 {
+			if lit, ok := e.Args[0].(*ast.BasicLit); ok && lit.Kind == token.INT {
+				code, _ := strconv.Atoi(lit.Value)
+				if n, ok := statusNames[code]; ok {
+					status = n
+					continue
+				}
+			}
+		}
+		list = append(list, stmt)
+	}
+	if status == "" {
+		for _, n := range statusNames {
+			if n == name {
+				status = name
+			}
+		}
+	}
+	if status == "" {
+		pass.Report(analysis.Diagnostic{Pos: expr.Args[0].Pos(), Message: fmt.Sprintf(`status code of response %q should be converted manually`, name)})
+		return false
+	}
+	expr.Args = []ast.Expr{&ast.Ident{NamePos: expr.Args[0].Pos(), Name: status}}
+	if len(list) > 0 {
+		if dsl == nil {
+			dsl = &ast.FuncLit{Type: &ast.FuncType{}, Body: &ast.BlockStmt{}}
+		}
+		dsl.Body.List = list
+		expr.Args = append(expr.Args, dsl)
+	}
+	return true
+}
+
+// findNestedCall returns the first call of the DSL named name in node.
+func findNestedCall(node ast.Node, name string) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		if expr, ok := n.(*ast.CallExpr); ok {
+			if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == name {
+				found = expr
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// findParam returns Param named name in list.
+func findParam(list []ast.Stmt, name string) ast.Stmt {
+	for _, stmt := range list {
+		if e := findCall([]ast.Stmt{stmt}, "Param"); e != nil && len(e.Args) > 0 {
+			if n, ok := stringValue(e.Args[0]); ok && n == name {
+				return stmt
+			}
+		}
+	}
+	return nil
+}
+
+// findAttributeType returns the type of the attribute named name in list.
+func findAttributeType(list []ast.Stmt, name string) ast.Expr {
+	for _, stmt := range list {
+		e := findCall([]ast.Stmt{stmt}, "Attribute")
+		if e == nil || len(e.Args) < 2 {
+			continue
+		}
+		if n, ok := stringValue(e.Args[0]); !ok || n != name {
+			continue
+		}
+		switch e.Args[1].(type) {
+		case *ast.BasicLit, *ast.FuncLit:
+			return nil
+		}
+		return e.Args[1]
+	}
+	return nil
+}
+
+// findCall returns the first call of the DSL with name in list.
+func findCall(list []ast.Stmt, name string) *ast.CallExpr {
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == name {
+			return expr
+		}
+	}
+	return nil
+}
+
+// hasOKResponse reports whether list has Response with OK, "OK" or OK qualified by the package name.
+func hasOKResponse(pass *analysis.Pass, list []ast.Stmt) bool {
+	for _, stmt := range list {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		expr, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(expr.Args) == 0 {
+			continue
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); !ok || ident.Name != "Response" {
+			continue
+		}
+		if name, ok := constantString(pass, expr.Args[0]); ok && name == "OK" {
+			return true
+		}
+	}
+	return false
+}
+
+// capitalize returns word with the first letter in upper case, or entirely in upper case if it is an initialism.
+func capitalize(word string) string {
+	if upper := strings.ToUpper(word); initialisms[upper] {
+		return upper
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func hasValue(m map[string]string, s string) bool {
+	for _, v := range m {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// indexStmt returns the index of the statement formatted as b in list, or -1 if it is not present.
+func indexStmt(fset *token.FileSet, list []ast.Stmt, b []byte) int {
+	for i, stmt := range list {
+		if bytes.Equal(formatNode(fset, stmt), b) {
+			return i
+		}
+	}
+	return -1
+}
+
+func lookupSecurityScheme(defs *definitions, expr ast.Expr) *securityScheme {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return defs.schemes[expr.Name]
+	case *ast.BasicLit:
+		name, _ := stringValue(expr)
+		for _, scheme := range defs.schemes {
+			if scheme.name == name {
+				return scheme
+			}
+		}
+	}
+	return nil
+}
+
+// moveAttributes adds the attributes declared in the statements of Params or Headers to body, and maps them by Param or Header in mapping.
+// pathParams holds the names of the path parameters keyed by the names of the attributes,
+// and the attributes are not mapped if they are the path parameters with the same names.
+// The report is called with the name of each added attribute.
+func moveAttributes(list []ast.Stmt, group string, body *ast.BlockStmt, mapping *[]ast.Stmt, pathParams map[string]string, report func(attr string)) bool {
+	dsl := "Param"
+	if group == "Headers" {
+		dsl = "Header"
+	}
+	attributeName := func(name string) string {
+		if dsl == "Header" {
+			return strcase.ToSnake(name)
+		}
+		return name
+	}
+	var (
+		changed bool
+		moved   []string
+	)
+	for _, stmt := range list {
+		stmt := cloneStmt(stmt)
+		s, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		e, ok := s.X.(*ast.CallExpr)
+		if !ok || len(e.Args) == 0 {
+			continue
+		}
+		i, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch i.Name {
+		case dsl:
+			name, ok := stringValue(e.Args[0])
+			if !ok {
+				continue
+			}
+			attr := attributeName(name)
+			if hasAttribute(body.List, attr) {
+				continue
+			}
+			report(attr)
+			moved = append(moved, attr)
+			i.Name = "Attribute"
+			e.Args[0] = newStringLit(attr)
+			key, ok := pathParams[name]
+			if !ok {
+				key = name
+			}
+			if !ok || key != attr {
+				if attr != key {
+					attr += ":" + key
+				}
+				*mapping = append(*mapping, newCallStmt(dsl, newStringLit(attr)))
+			}
+		case "Required":
+			var args []ast.Expr
+			for _, arg := range e.Args {
+				if name, ok := stringValue(arg); ok && hasString(moved, attributeName(name)) {
+					args = append(args, newStringLit(attributeName(name)))
+				}
+			}
+			if len(args) == 0 {
+				continue
+			}
+			e.Args = args
+		default:
+			continue
+		}
+		// The statements are converted without diagnostics since they are reported where they are declared.
+		analyzeGenericDSL(func(analysis.Diagnostic) {}, stmt)
+		body.List = append(body.List, stmt)
+		changed = true
+	}
+	return changed
+}
+
+// lastFuncLit returns the DSL given to expr as the last argument, and adds one if there is not.
+func lastFuncLit(expr *ast.CallExpr) *ast.FuncLit {
+	if len(expr.Args) > 0 {
+		if e, ok := expr.Args[len(expr.Args)-1].(*ast.FuncLit); ok {
+			return e
+		}
+	}
+	e := &ast.FuncLit{
+		Type: &ast.FuncType{},
+		Body: &ast.BlockStmt{},
+	}
+	expr.Args = append(expr.Args, e)
+	return e
+}
+
+func newCallStmt(name string, args ...ast.Expr) *ast.ExprStmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.Ident{
+				Name: name,
+			},
+			Args: args,
+		},
+	}
+}
+
+func newStringLit(s string) *ast.BasicLit {
+	return &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: strconv.Quote(s),
+	}
+}
+
+// substituteParams replaces the arguments referring to the parameters of a response template with their values,
+// and places the identifiers and the literals at pos so that diagnostics for them can be reported.
+func substituteParams(node ast.Node, values map[string]ast.Expr, pos token.Pos) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.BasicLit:
+			if !e.ValuePos.IsValid() {
+				e.ValuePos = pos
+			}
+		case *ast.Ident:
+			if !e.NamePos.IsValid() {
+				e.NamePos = pos
+			}
+		case *ast.CallExpr:
+			for i, arg := range e.Args {
+				if ident, ok := arg.(*ast.Ident); ok {
+					if v, ok := values[ident.Name]; ok {
+						e.Args[i] = cloneExpr(v)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// designIdent returns the identifier of expr if it is qualified by the goa design package such as design.OK.
+func designIdent(pass *analysis.Pass, expr ast.Expr) (*ast.Ident, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	pkg, ok := pass.TypesInfo.Uses[x].(*types.PkgName)
+	if !ok || pkg.Imported().Path() != "github.com/goadesign/goa/design" {
+		return nil, false
+	}
+	return sel.Sel, true
+}
+
+// constantString returns the value of expr if it is a constant string.
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func stringValue(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+func replaceCatchAll(s string) string {
+	return regexpCatchAll.ReplaceAllString(s, "/{*$1}")
 }
 
 func replaceWildcard(s string) string {
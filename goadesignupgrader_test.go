@@ -9,5 +9,5 @@ import (
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, goadesignupgrader.Analyzer, "design")
+	analysistest.Run(t, testdata, goadesignupgrader.Analyzer, "design", "security", "response", "trait", "cors", "routing", "parent", "link", "mediatype", "payload")
 }
@@ -0,0 +1,26 @@
+package cors
+
+import ( // want `\Aimport declarations should be fixed\z` `\A"goa.design/plugins/v3/cors/dsl" should be imported as cors for Origin\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var _ = API("cors", func() { // want `\Avariable declarations should be fixed\z`
+	Origin("http://swagger.goa.design", func() { // want `\AOrigin should be replaced with cors.Origin\z`
+		Methods("GET", "POST")     // want `\AMethods should be replaced with cors.Methods\z`
+		Headers("X-Shared-Secret") // want `\AHeaders should be replaced with cors.Headers\z`
+		Expose("X-Time")           // want `\AExpose should be replaced with cors.Expose\z`
+		MaxAge(600)                // want `\AMaxAge should be replaced with cors.MaxAge\z`
+		Credentials()              // want `\ACredentials should be replaced with cors.Credentials\z`
+	})
+})
+
+var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Origin("*", func() { // want `\AOrigin should be replaced with cors.Origin\z`
+		Methods("GET") // want `\AMethods should be replaced with cors.Methods\z`
+	})
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)      // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
@@ -10,16 +10,51 @@ var _ = API("api", func() { // want `\Avariable declarations should be fixed\z`
 	BasePath("/:version")                           // want `\ABasePath should be replaced with Path and wrapped by HTTP\z` `\Acolons in BasePath should be replaced with curly braces\z`
 	Consumes("application/json", "application/xml") // want `\AConsumes should be wrapped by HTTP\z`
 	Produces("application/json", "application/xml") // want `\AProduces should be wrapped by HTTP\z`
-	Params(func() {                                 // want `\AParams should be wrapped by HTTP\z`
+	Params(func() {                                 // want `\AParams should be moved to Payload of each Method and mapped by HTTP\z`
 		Param("version")
 	})
+	Host("api.example.com") // want `\AHost should be replaced with URI of Host in Server\z`
+	Scheme("http", "https") // want `\AScheme should be replaced with the scheme of URI of Host in Server\z`
+	Title("API")
+	Version("1.0")
+	TermsOfService("https://example.com/terms")
+	Contact(func() {
+		Name("goa")
+		Email("goa@example.com")
+		URL("https://example.com")
+	})
+	License(license)
+	Docs(func() {
+		Description("docs")
+		URL("https://example.com/docs")
+	})
 })
 
+var license = func() {
+	Name("MIT")
+	URL("https://example.com/license")
+}
+
 var User = Type("user", func() { // want `\Avariable declarations should be fixed\z`
 	Attribute("permissions", HashOf(String, Boolean)) // want `\AHashOf should be replaced with MapOf\z`
+	Attribute("email", String, func() {
+		Format("email") // want `\A"email" should be replaced with FormatEmail\z`
+	})
+	Attribute("homepage", String, func() {
+		Format("uri") // want `\A"uri" should be replaced with FormatURI\z`
+	})
+	Attribute("updated_at", String, func() {
+		Format("date-time") // want `\A"date-time" should be replaced with FormatDateTime\z`
+	})
+	Attribute("pattern", String, func() {
+		Format("regexp") // want `\A"regexp" should be replaced with FormatRegexp\z`
+	})
+	Attribute("isbn", String, func() {
+		Format("isbn") // want `\Aformat "isbn" has no equivalent in v3 and should be fixed manually\z`
+	})
 })
 
-var UserMedia = MediaType("application/vnd.user+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z`
+var UserMedia = MediaType("application/vnd.user+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "User" should be added to keep the name of the type generated by goagen\z`
 	Attribute("id", Integer)                          // want `\AInteger should be replaced with Int\z`
 	Attribute("permissions", HashOf(String, Boolean)) // want `\AHashOf should be replaced with MapOf\z`
 	Attribute("interests", HashOf(String, Integer,    // want `\AHashOf should be replaced with MapOf\z` `\AInteger should be replaced with Int\z`
@@ -31,27 +66,40 @@ var UserMedia = MediaType("application/vnd.user+json", func() { // want `\Avaria
 			Maximum(5)
 		},
 	))
-	Attribute("created_at", DateTime) // want `\ADateTime should be replaced with String \+ Format\(FormatDateTime\)\z`
+	Attribute("created_at", DateTime)                  // want `\ADateTime should be replaced with String \+ Format\(FormatDateTime\)\z`
+	Attribute("uuid", UUID)                            // want `\AUUID should be replaced with String \+ Format\(FormatUUID\)\z`
+	Attribute("logins", ArrayOf(DateTime))             // want `\ADateTime should be replaced with String \+ Format\(FormatDateTime\)\z`
+	Attribute("sessions", HashOf(UUID, DateTime))      // want `\AHashOf should be replaced with MapOf\z` `\AUUID should be replaced with String \+ Format\(FormatUUID\)\z` `\ADateTime should be replaced with String \+ Format\(FormatDateTime\)\z`
+	Attribute("devices", HashOf(UUID, String, func() { // want `\AHashOf should be replaced with MapOf\z` `\AUUID should be replaced with String \+ Format\(FormatUUID\)\z` `\Aoptional DSL for key of HashOf should be set by Key\z`
+		MinLength(1)
+	}))
 })
 
 var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
 	BasePath("/users")          // want `\ABasePath should be replaced with Path and wrapped by HTTP\z`
 	CanonicalActionName("show") // want `\ACanonicalActionName should be replaced with CanonicalMethod and wrapped by HTTP\z`
-	DefaultMedia(UserMedia)     // want `\ADefaultMedia should be removed\z`
-	Headers(func() {            // want `\AHeaders should be wrapped by HTTP\z`
+	DefaultMedia(UserMedia)     // want `\ADefaultMedia should be replaced with Result of each Method\z`
+	Headers(func() {            // want `\AHeaders should be moved to Payload of each Method and mapped by HTTP\z`
 		Header("Time-Zone")
+		Required("Time-Zone")
 	})
-	Params(func() { // want `\AParams should be wrapped by HTTP\z`
+	Params(func() { // want `\AParams should be moved to Payload of each Method and mapped by HTTP\z`
 		Param("token")
+		Required("token")
 	})
-	Action("show", func() { // want `\AAction should be replaced with Method\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for Params of Resource\z` `\Apayload attribute "version" should be added for Params of API\z` `\Apayload attribute "time_zone" should be added for Headers of Resource\z` `\Apayload attribute "user_id" should be added for the path parameter\z`
 		Routing(GET("/:user_id")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
-		Headers(func() {          // want `\AHeaders should be wrapped by HTTP\z`
+		Headers(func() {          // want `\AHeaders should be replaced with Payload attributes and Header mappings in HTTP\z`
 			Header("Link")
+			Header("X-Request-Id", String, "Request ID")
+			Required("X-Request-Id")
 		})
 		Response(OK, func() { // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
-			Media(UserMedia)      // want `\AMedia for a non-error response should be replaced with Result and wrapped by HTTP in the parent\z`
+			Media(UserMedia)      // want `\AMedia for a non-error response should be replaced with Result and wrapped by HTTP in the parent\z` `\AResult with a type should be replaced with an inline result using Extend\z`
 			Status(http.StatusOK) // want `\AStatus should be replaced with Code\z`
+			Headers(func() {      // want `\AHeaders in Response should be replaced with Result attributes and Header mappings\z`
+				Header("ETag", String)
+			})
 		})
 		Response(NotFound, func() { // want `\AResponse should be wrapped by HTTP\z` `\ANotFound should be replaced with StatusNotFound\z`
 			Media(ErrorMedia)           // want `\AMedia for an error response should be removed\z`
@@ -59,19 +107,31 @@ var _ = Resource("user", func() { // want `\Avariable declarations should be fix
 		})
 		Metadata("swagger:summary", "Show users") // want `\AMetadata should be replaced with Meta\z`
 	})
-	Action("list", func() { // want `\AAction should be replaced with Method\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for Params of Resource\z` `\Apayload attribute "version" should be added for Params of API\z` `\Apayload attribute "time_zone" should be added for Headers of Resource\z`
 		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
-		Params(func() {   // want `\AParams should be wrapped by HTTP\z`
-			Param("page")
+		Params(func() {   // want `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+			Param("page", Integer, "Page number", func() { // want `\AInteger should be replaced with Int\z`
+				Minimum(1)
+			})
+			Required("page")
 		})
-		Response(OK, CollectionOf(UserMedia)) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+		Response(OK, CollectionOf(UserMedia)) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z` `\Amedia of a non-error response should be replaced with Result\z`
 		Response(BadRequest, ErrorMedia)      // want `\AResponse should be wrapped by HTTP\z` `\ABadRequest should be replaced with StatusBadRequest\z` `\AErrorMedia should be removed\z`
 	})
-	Action("create", func() { // want `\AAction should be replaced with Method\z`
-		Routing(POST("/")) // want `\ARouting should be replaced with HTTP\z`
-		Payload(User)
-		Response(Created, UserMedia, func() { // want `\AResponse should be wrapped by HTTP\z` `\ACreated should be replaced with StatusCreated\z`
-			Headers(func() { // No need to fix Header in Response.
+	Action("search", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "version" should be added for Params of API\z`
+		Routing(GET("/search")) // want `\ARouting should be replaced with HTTP\z`
+		Params(func() {         // want `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+			Param("token", String, "Search token")
+		})
+		Headers(func() { // want `\AHeaders should be replaced with Payload attributes and Header mappings in HTTP\z`
+			Header("Time-Zone", String, "Time zone of the results")
+		})
+	})
+	Action("create", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for Params of Resource\z` `\Apayload attribute "version" should be added for Params of API\z` `\Apayload attribute "time_zone" should be added for Headers of Resource\z`
+		Routing(POST("/"))                    // want `\ARouting should be replaced with HTTP\z`
+		Payload(User)                         // want `\APayload with a type should be replaced with an inline payload using Extend\z`
+		Response(Created, UserMedia, func() { // want `\AResponse should be wrapped by HTTP\z` `\ACreated should be replaced with StatusCreated\z` `\Amedia of a non-error response should be replaced with Result\z` `\AResult with a type should be replaced with an inline result using Extend\z`
+			Headers(func() { // want `\AHeaders in Response should be replaced with Result attributes and Header mappings\z`
 				Header("Location")
 			})
 		})
@@ -81,3 +141,21 @@ var _ = Resource("user", func() { // want `\Avariable declarations should be fix
 var _ = Resource("post", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
 	Parent("user") // want `\AParent should be wrapped by HTTP\z`
 })
+
+var _ = Resource("tag", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Headers(func() { // want `\AHeaders should be moved to Payload of each Method and mapped by HTTP\z`
+		Header("ETag")
+	})
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "version" should be added for Params of API\z` `\Apayload attribute "e_tag" should be added for Headers of Resource\z`
+		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
+	})
+})
+
+var _ = Resource("swagger", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Files("/swagger.json", "swagger/swagger.json") // want `\Aswagger/swagger.json is no longer generated and should be replaced with openapi.json generated in gen/http\z`
+	Files("/swagger/*filepath", "public/swagger/") // want `\Aasterisks in HTTP routing DSLs should be wrapped by curly braces\z`
+	Action("download", func() {                    // want `\AAction should be replaced with Method\z` `\Apayload attribute "version" should be added for Params of API\z` `\Apayload attribute "path" should be added for the path parameter\z`
+		Routing(GET("/download/*path")) // want `\ARouting should be replaced with HTTP\z` `\Aasterisks in HTTP routing DSLs should be wrapped by curly braces\z`
+		Response(OK)                    // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
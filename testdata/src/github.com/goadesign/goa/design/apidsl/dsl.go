@@ -119,3 +119,191 @@ func Parent(p string) {
 func DefaultMedia(val interface{}, viewName ...string) {
 	return
 }
+
+func BasicAuthSecurity(name string, dsl ...func()) interface{} {
+	return nil
+}
+
+func APIKeySecurity(name string, dsl ...func()) interface{} {
+	return nil
+}
+
+func JWTSecurity(name string, dsl ...func()) interface{} {
+	return nil
+}
+
+func OAuth2Security(name string, dsl ...func()) interface{} {
+	return nil
+}
+
+func Description(d string) {
+	return
+}
+
+func Query(parameterName string) {
+	return
+}
+
+func TokenURL(tokenURL string) {
+	return
+}
+
+func Scope(name string, desc ...string) {
+	return
+}
+
+func Security(scheme interface{}, dsl ...func()) {
+	return
+}
+
+func AccessCodeFlow(authorizationURL, tokenURL string) {
+	return
+}
+
+func ApplicationFlow(tokenURL string) {
+	return
+}
+
+func ImplicitFlow(authorizationURL string) {
+	return
+}
+
+func PasswordFlow(tokenURL string) {
+	return
+}
+
+func Required(names ...string) {
+	return
+}
+
+func ArrayOf(v interface{}, dsl ...func()) interface{} {
+	return nil
+}
+
+func NoSecurity() {
+	return
+}
+
+func PUT(path string, dsl ...func()) interface{} {
+	return nil
+}
+
+func View(name string, apidsl ...func()) {
+	return
+}
+
+func DELETE(path string, dsl ...func()) interface{} {
+	return nil
+}
+
+func ResponseTemplate(name string, p interface{}) {
+	return
+}
+
+func Trait(name string, val ...func()) {
+	return
+}
+
+func UseTrait(names ...string) {
+	return
+}
+
+func Host(host string) {
+	return
+}
+
+func Scheme(vals ...string) {
+	return
+}
+
+func Title(val string) {
+	return
+}
+
+func Version(ver string) {
+	return
+}
+
+func TermsOfService(terms string) {
+	return
+}
+
+func Contact(dsl func()) {
+	return
+}
+
+func License(dsl func()) {
+	return
+}
+
+func Docs(dsl func()) {
+	return
+}
+
+func Name(name string) {
+	return
+}
+
+func Email(email string) {
+	return
+}
+
+func URL(url string) {
+	return
+}
+
+func Origin(origin string, dsl func()) {
+	return
+}
+
+func Methods(vals ...string) {
+	return
+}
+
+func Expose(vals ...string) {
+	return
+}
+
+func MaxAge(val uint) {
+	return
+}
+
+func Credentials() {
+	return
+}
+
+func Files(path, filename string, dsls ...func()) {
+	return
+}
+
+func Format(f string) {
+	return
+}
+
+func Attributes(apidsl func()) {
+	return
+}
+
+func Links(apidsl func()) {
+	return
+}
+
+func Link(name string, view ...string) {
+	return
+}
+
+func ContentType(typ string) {
+	return
+}
+
+func TypeName(name string) {
+	return
+}
+
+func OptionalPayload(p interface{}, dsls ...func()) {
+	return
+}
+
+func Member(name string, args ...interface{}) {
+	return
+}
@@ -3,6 +3,8 @@ package design
 const (
 	OK         = "OK"
 	Created    = "Created"
+	Accepted   = "Accepted"
+	NoContent  = "NoContent"
 	BadRequest = "BadRequest"
 	NotFound   = "NotFound"
 
@@ -10,6 +12,7 @@ const (
 	Integer  = "Integer"
 	String   = "String"
 	DateTime = "DateTime"
+	UUID     = "UUID"
 
 	ErrorMedia = "ErrorMedia"
 )
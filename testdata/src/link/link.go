@@ -0,0 +1,52 @@
+package link
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var AccountMedia = MediaType("application/vnd.account+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Account" should be added to keep the name of the type generated by goagen\z`
+	Attribute("id", Integer) // want `\AInteger should be replaced with Int\z`
+	Attribute("name", String)
+	View("default", func() {
+		Attribute("id")
+		Attribute("name")
+	})
+	View("link", func() {
+		Attribute("id")
+	})
+})
+
+var OriginMedia = MediaType("application/vnd.origin+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Origin" should be added to keep the name of the type generated by goagen\z`
+	Attribute("country", String)
+	View("default", func() {
+		Attribute("country")
+	})
+})
+
+var BottleMedia = MediaType("application/vnd.bottle+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Bottle" should be added to keep the name of the type generated by goagen\z`
+	Attributes(func() {
+		Attribute("id", Integer) // want `\AInteger should be replaced with Int\z`
+		Attribute("account", AccountMedia)
+		Attribute("origin", OriginMedia)
+	})
+	Links(func() { // want `\ALinks should be replaced with attribute "links"\z`
+		Link("account")        // want `\AJSON shape of link "account" should be checked since it is rendered by view "link" in attribute "links"\z`
+		Link("origin", "tiny") // want `\Aview "tiny" is not defined in OriginMedia\z` `\AJSON shape of link "origin" should be checked since it is rendered by view "tiny" in attribute "links"\z`
+		Link("missing")        // want `\Aattribute "missing" linked by Link is not defined and should be fixed manually\z`
+	})
+	View("default", func() {
+		Attribute("id")
+		Attribute("links")
+	})
+})
+
+var BoxMedia = MediaType("application/vnd.box+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Box" should be added to keep the name of the type generated by goagen\z`
+	Attribute("accounts", CollectionOf(AccountMedia))
+	Links(func() { // want `\ALinks should be replaced with attribute "links"\z`
+		Link("accounts") // want `\AJSON shape of link "accounts" should be checked since it is rendered by view "link" in attribute "links"\z`
+	})
+	View("default", func() {
+		Attribute("links")
+	})
+})
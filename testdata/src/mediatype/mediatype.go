@@ -0,0 +1,38 @@
+package mediatype
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+const BottleID = "application/vnd.goa.example.bottle+json"
+
+var BottleMedia = MediaType(BottleID, func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "GoaExampleBottle" should be added to keep the name of the type generated by goagen\z`
+	ContentType("application/json")
+	Attribute("name", String)
+})
+
+var UserIDMedia = MediaType("application/vnd.user-id+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "UserID" should be added to keep the name of the type generated by goagen\z`
+	Attribute("id", String)
+})
+
+var UsersMedia = MediaType("application/vnd.user+json;type=collection", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\Aidentifier should be replaced with "application/vnd.user\+json; type=collection"\z` `\ATypeName "User" should be added to keep the name of the type generated by goagen\z`
+	Attribute("names", ArrayOf(String))
+})
+
+var BottlesMedia = MediaType("application/vnd.goa.example.bottle+json; type=collection", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "GoaExampleBottle" should be added to keep the name of the type generated by goagen\z`
+	Attribute("names", ArrayOf(String))
+})
+
+var FaultMedia = MediaType("fault", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Fault" should be added to keep the name of the type generated by goagen\z`
+	Attribute("message", String)
+})
+
+var AccountMedia = MediaType("application/vnd.account+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z`
+	TypeName("Account")
+	Attribute("name", String)
+})
+
+var InvalidMedia = MediaType("application/vnd.invalid+json; =", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\Aidentifier "application/vnd.invalid\+json; =" is invalid and should be fixed manually\z`
+	Attribute("name", String)
+})
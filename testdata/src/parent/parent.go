@@ -0,0 +1,66 @@
+package parent
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var _ = Resource("account", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	BasePath("/accounts")   // want `\ABasePath should be replaced with Path and wrapped by HTTP\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/:id")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Params(func() {      // want `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+			Param("id", Integer) // want `\AInteger should be replaced with Int\z`
+		})
+		Response(OK) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("bottle", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("account")          // want `\AParent should be wrapped by HTTP\z`
+	BasePath("/bottles")       // want `\ABasePath should be replaced with Path and wrapped by HTTP\z`
+	CanonicalActionName("get") // want `\ACanonicalActionName should be replaced with CanonicalMethod and wrapped by HTTP\z`
+	Action("get", func() {     // want `\AAction should be replaced with Method\z` `\Apayload attribute "accountID" should be added for Params of Parent\z` `\Apayload attribute "bottleID" should be added for the path parameter\z`
+		Routing(GET("/:bottleID")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Response(OK)               // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("list", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "accountID" should be added for Params of Parent\z`
+		Routing(GET("")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)     // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("review", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("bottle")        // want `\AParent should be wrapped by HTTP\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "accountID" should be added for Params of Parent\z` `\Apayload attribute "bottleID" should be added for Params of Parent\z`
+		Routing(GET("")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)     // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("profile", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("account")       // want `\AParent should be wrapped by HTTP\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "accountID" should be added for Params of Parent\z` `\Apayload attribute "id" should be added for the path parameter\z`
+		Routing(GET("/:id")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z` `\Apath parameter "id" conflicts with the one of Parent and should be renamed manually\z`
+		Response(OK)         // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("transfer", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("account")       // want `\AParent should be wrapped by HTTP\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/transfers/:transferID")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Params(func() {                        // want `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+			Param("accountID", Integer, "Account ID") // want `\AInteger should be replaced with Int\z`
+			Param("transferID", String)
+		})
+	})
+})
+
+var _ = Resource("orphan", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("missing")       // want `\AParent should be wrapped by HTTP\z` `\Aparent "missing" cannot be resolved\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)      // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
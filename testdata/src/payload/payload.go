@@ -0,0 +1,57 @@
+package payload
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var User = Type("user", func() {
+	Attribute("name", String)
+	Attribute("email", String)
+	Required("name")
+})
+
+var Filter = Type("filter", func() { // want `\Avariable declarations should be fixed\z`
+	Attribute("query", String)
+	Member("limit", Integer) // want `\AMember should be replaced with Attribute\z` `\AInteger should be replaced with Int\z`
+})
+
+var QueryMedia = MediaType("application/vnd.query+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "Query" should be added to keep the name of the type generated by goagen\z`
+	Attributes(func() {
+		Member("query", String) // want `\AMember should be replaced with Attribute\z`
+	})
+})
+
+var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("create", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(func() {
+			Member("name", String)            // want `\AMember should be replaced with Attribute\z`
+			Member("born", DateTime, func() { // want `\AMember should be replaced with Attribute\z` `\ADateTime should be replaced with String \+ Format\(FormatDateTime\)\z`
+				Member("year", Integer) // want `\AMember should be replaced with Attribute\z` `\AInteger should be replaced with Int\z`
+			})
+			Required("name")
+		})
+	})
+	Action("update", func() { // want `\AAction should be replaced with Method\z`
+		Routing(PUT("/")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(User, func() {
+			Required("email") // want `\ARequired in Payload should be checked since it is combined with the attributes required by User\z`
+		})
+	})
+	Action("search", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/search")) // want `\ARouting should be replaced with HTTP\z`
+		OptionalPayload(Filter)  // want `\AOptionalPayload should be replaced with Payload\z`
+	})
+	Action("patch", func() { // want `\AAction should be replaced with Method\z`
+		Routing(PUT("/patch")) // want `\ARouting should be replaced with HTTP\z`
+		OptionalPayload(User)  // want `\AOptionalPayload should be replaced with Payload\z` `\Aattributes required by User make the optional payload required and should be fixed manually\z`
+	})
+	Action("import", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/import")) // want `\ARouting should be replaced with HTTP\z`
+		OptionalPayload(func() { // want `\AOptionalPayload should be replaced with Payload\z`
+			Member("url", String) // want `\AMember should be replaced with Attribute\z`
+			Required("url")       // want `\ARequired in OptionalPayload makes the payload required and should be fixed manually\z`
+		})
+	})
+})
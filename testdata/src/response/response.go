@@ -0,0 +1,176 @@
+package response
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	design "github.com/goadesign/goa/design"   // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var UserMedia = MediaType("application/vnd.user+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "User" should be added to keep the name of the type generated by goagen\z`
+	Attribute("name", String)
+	View("default", func() {
+		Attribute("name")
+	})
+	View("tiny", func() {
+		Attribute("name")
+	})
+})
+
+var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("update", func() { // want `\AAction should be replaced with Method\z`
+		Routing(PUT("/"))             // want `\ARouting should be replaced with HTTP\z`
+		Response(OK, UserMedia)       // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z` `\Amedia of a non-error response should be replaced with Result\z`
+		Response(Accepted, UserMedia) // want `\AResponse should be wrapped by HTTP\z` `\AAccepted should be replaced with StatusAccepted\z` `\Amedia of a non-error response should be replaced with Result\z`
+	})
+	Action("list", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/"))                           // want `\ARouting should be replaced with HTTP\z`
+		Response(OK, UserMedia)                     // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z` `\Amedia of a non-error response should be replaced with Result\z`
+		Response(Accepted, CollectionOf(UserMedia)) // want `\AResponse should be wrapped by HTTP\z` `\AAccepted should be replaced with StatusAccepted\z` `\Amedia of a non-error response should be replaced with Result\z` `\Amedia of the response conflicts with Result of another response and should be fixed manually\z`
+	})
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "id" should be added for the path parameter\z`
+		Routing(GET("/:id"))  // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Response(OK, func() { // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+			Media(UserMedia, "tiny") // want `\AMedia for a non-error response should be replaced with Result and wrapped by HTTP in the parent\z` `\Aview should be set by View in the DSL of Result\z`
+		})
+	})
+	Action("index", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/index")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK, func() {  // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+			Media(CollectionOf(UserMedia), "compact") // want `\AMedia for a non-error response should be replaced with Result and wrapped by HTTP in the parent\z` `\Aview should be set by View in the DSL of Result\z` `\Aview "compact" is not defined in UserMedia\z`
+		})
+	})
+})
+
+var _ = Resource("account", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	DefaultMedia(UserMedia) // want `\ADefaultMedia should be replaced with Result of each Method\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\AResult should be added for DefaultMedia of Resource\z` `\AReference should be added to Payload for DefaultMedia of Resource\z` `\Apayload attribute "id" should be added for the path parameter\z`
+		Routing(GET("/:id")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Params(func() {      // want `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+			Param("name")
+		})
+		Response(OK) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("delete", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "id" should be added for the path parameter\z`
+		Routing(DELETE("/:id")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Response(NoContent)     // want `\AResponse should be wrapped by HTTP\z` `\ANoContent should be replaced with StatusNoContent\z`
+	})
+})
+
+var _ = Resource("profile", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	DefaultMedia(UserMedia, "tiny") // want `\ADefaultMedia should be replaced with Result of each Method\z` `\Aview should be set by View in the DSL of Result\z`
+	Action("show", func() {         // want `\AAction should be replaced with Method\z` `\AResult should be added for DefaultMedia of Resource\z`
+		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)      // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("list", func() { // want `\AAction should be replaced with Method\z` `\AResult should be added for DefaultMedia of Resource\z`
+		Routing(GET("/list")) // want `\ARouting should be replaced with HTTP\z`
+		Response("OK")        // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("index", func() { // want `\AAction should be replaced with Method\z` `\AResult should be added for DefaultMedia of Resource\z`
+		Routing(GET("/index")) // want `\ARouting should be replaced with HTTP\z`
+		Response(design.OK)    // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("tag", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	DefaultMedia(UserMedia) // want `\ADefaultMedia should be replaced with Result of each Method\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\AExtend should be added to Result for DefaultMedia of Resource\z`
+		Routing(GET("/tag"))  // want `\ARouting should be replaced with HTTP\z`
+		Response(OK, func() { // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+			Headers(func() { // want `\AHeaders in Response should be replaced with Result attributes and Header mappings\z`
+				Header("ETag")
+			})
+		})
+	})
+})
+
+var _ = Resource("history", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("create", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/history"))  // want `\ARouting should be replaced with HTTP\z`
+		Response(Created, func() { // want `\AResponse should be wrapped by HTTP\z` `\ACreated should be replaced with StatusCreated\z`
+			Headers(func() { // want `\AHeaders in Response should be replaced with Result attributes and Header mappings\z`
+				Header("Location")
+			})
+		})
+		Response(OK, UserMedia) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z` `\Amedia of a non-error response should be replaced with Result\z`
+	})
+	Action("update", func() { // want `\AAction should be replaced with Method\z`
+		Routing(PUT("/history"))   // want `\ARouting should be replaced with HTTP\z`
+		Response(OK, UserMedia)    // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z` `\Amedia of a non-error response should be replaced with Result\z` `\AResult with a type should be replaced with an inline result using Extend\z`
+		Response(Created, func() { // want `\AResponse should be wrapped by HTTP\z` `\ACreated should be replaced with StatusCreated\z`
+			Headers(func() { // want `\AHeaders in Response should be replaced with Result attributes and Header mappings\z`
+				Header("Location")
+			})
+		})
+	})
+})
+
+var CustomErrorMedia = MediaType("application/vnd.custom-error+json", func() { // want `\Avariable declarations should be fixed\z` `\AMediaType should be replaced with ResultType\z` `\ATypeName "CustomError" should be added to keep the name of the type generated by goagen\z`
+	Attribute("message", String)
+	View("default", func() {
+		Attribute("message")
+	})
+})
+
+var _ = Resource("session", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z` `\AError\("bad_request", CustomErrorMedia\) should be moved to Service since every Method has it\z`
+	Action("create", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/"))                     // want `\ARouting should be replaced with HTTP\z`
+		Response(BadRequest, CustomErrorMedia) // want `\AResponse should be wrapped by HTTP\z` `\ABadRequest should be replaced with StatusBadRequest\z` `\Amedia of an error response should be moved to Error\z`
+		Response(NotFound, func() {            // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "NotFound" should be expanded with its definition\z` `\ANotFound should be replaced with StatusNotFound\z`
+			Media(CustomErrorMedia) // want `\Amedia of an error response should be moved to Error\z`
+		})
+	})
+	Action("delete", func() { // want `\AAction should be replaced with Method\z`
+		Routing(DELETE("/"))                   // want `\ARouting should be replaced with HTTP\z`
+		Response(BadRequest, CustomErrorMedia) // want `\AResponse should be wrapped by HTTP\z` `\ABadRequest should be replaced with StatusBadRequest\z` `\Amedia of an error response should be moved to Error\z`
+	})
+})
+
+var _ = Resource("login", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z` `\AError\("bad_request"\) should be moved to Service since every Method has it\z`
+	Action("create", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/login"))                        // want `\ARouting should be replaced with HTTP\z`
+		Response(design.BadRequest, design.ErrorMedia) // want `\AResponse should be wrapped by HTTP\z` `\ABadRequest should be replaced with StatusBadRequest\z` `\AErrorMedia should be removed\z`
+	})
+	Action("delete", func() { // want `\AAction should be replaced with Method\z`
+		Routing(DELETE("/login"))            // want `\ARouting should be replaced with HTTP\z`
+		Response(design.BadRequest, func() { // want `\AResponse should be wrapped by HTTP\z` `\ABadRequest should be replaced with StatusBadRequest\z`
+			Media(design.ErrorMedia) // want `\AMedia for an error response should be removed\z`
+		})
+	})
+})
+
+var _ = API("response", func() { // want `\Avariable declarations should be fixed\z`
+	Description("response")
+	ResponseTemplate("Paginated", func(mt string) { // want `\AResponseTemplate should be removed since it is expanded in each Response using it\z`
+		Status(200)
+		Media(mt)
+	})
+	Response("Teapot", func() { // want `\AResponse should be removed since it is expanded in each Response using it\z`
+		Status(418)
+		Description("I'm a teapot")
+	})
+	Response(NotFound, func() { // want `\AResponse should be removed since it is expanded in each Response using it\z`
+		Description("Tea not found")
+	})
+	Response("Bare") // want `\AResponse cannot be resolved and should be converted manually\z`
+})
+
+var _ = Resource("tea", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/"))                                  // want `\ARouting should be replaced with HTTP\z`
+		Response("Paginated", "application/vnd.user+json") // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "Paginated" should be expanded with its definition\z` `\AOK should be replaced with StatusOK\z` `\AMedia for a non-error response should be replaced with Result and wrapped by HTTP in the parent\z` `\Amedia type identifier should be replaced with UserMedia\z`
+		Response("Teapot")                                 // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "Teapot" should be expanded with its definition\z` `\ATeapot should be replaced with StatusTeapot\z`
+	})
+	Action("brew", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/"))           // want `\ARouting should be replaced with HTTP\z`
+		Response("Brewing", func() { // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "Brewing" should be expanded with its definition\z` `\AAccepted should be replaced with StatusAccepted\z`
+			Status(202)
+		})
+		Response("NotFound") // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "NotFound" should be expanded with its definition\z` `\ANotFound should be replaced with StatusNotFound\z`
+		Response("Unknown")  // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "Unknown" cannot be resolved\z`
+	})
+	Action("steep", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/steep")) // want `\ARouting should be replaced with HTTP\z`
+		Response(NotFound)      // want `\AResponse should be wrapped by HTTP\z` `\Aresponse "NotFound" should be expanded with its definition\z` `\ANotFound should be replaced with StatusNotFound\z`
+	})
+})
@@ -0,0 +1,51 @@
+package routing
+
+import ( // want `\Aimport declarations should be fixed\z`
+	"strings"
+
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+const ( // want `\Aconstant declarations should be fixed\z`
+	prefix   = "/users"
+	userPath = "/:id"            // want `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+	filePath = prefix + "/*path" // want `\Aasterisks in HTTP routing DSLs should be wrapped by curly braces\z`
+	helpPath = "/help/:topic"
+)
+
+var variablePath = "/:name"
+
+var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/"), GET("/list")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)                    // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "id" should be added for the path parameter\z`
+		Routing(GET(prefix+"/:id"), GET(userPath)) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Response(OK)                               // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("download", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "path" should be added for the path parameter\z`
+		Routing(GET(filePath)) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)           // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("posts", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "id" should be added for the path parameter\z` `\Apayload attribute "postID" should be added for the path parameter\z`
+		Routing(GET(userPath + "/posts/:postID")) // want `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z`
+		Response(OK)                              // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("help", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "topic" should be added for the path parameter\z`
+		Description("See " + helpPath)
+		Routing(GET(helpPath)) // want `\ARouting should be replaced with HTTP\z` `\AhelpPath is used outside HTTP routing DSLs and its wildcards should be fixed manually\z`
+		Response(OK)           // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("tagged", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/tagged", func() { // want `\ARouting should be replaced with HTTP\z` `\ADSL of GET should be moved to HTTP\z`
+			Metadata("swagger:tag", "tagged") // want `\AMetadata should be replaced with Meta\z`
+		}))
+		Response(OK) // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("lower", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET(strings.ToLower("/:id")), GET(variablePath)) // want `\ARouting should be replaced with HTTP\z` `\Apath should be checked for wildcards and fixed manually\z` `\AvariablePath should be checked for wildcards and fixed manually\z`
+		Response(OK)                                             // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
@@ -0,0 +1,130 @@
+package security
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var BasicAuth = BasicAuthSecurity("basic", func() {
+	Description("Use client ID and client secret to authenticate")
+})
+
+var APIKey = APIKeySecurity("api_key", func() { // want `\Avariable declarations should be fixed\z`
+	Description("Secures endpoint by requiring an API key.")
+	Header("X-Key") // want `\AHeader in APIKeySecurity should be moved to HTTP of secured methods\z`
+})
+
+var QueryKey = APIKeySecurity("query_key", func() { // want `\Avariable declarations should be fixed\z`
+	Query("key") // want `\AQuery in APIKeySecurity should be moved to HTTP of secured methods\z`
+})
+
+var JWT = JWTSecurity("jwt", func() { // want `\Avariable declarations should be fixed\z`
+	Header("Authorization")               // want `\AHeader in JWTSecurity should be moved to HTTP of secured methods\z`
+	TokenURL("https://example.com/token") // want `\ATokenURL in JWTSecurity should be removed\z`
+	Scope("api:read", "Read access")
+	Scope("api:write", "Write access")
+})
+
+var OAuth2 = OAuth2Security("oauth2", func() { // want `\Avariable declarations should be fixed\z`
+	AccessCodeFlow("https://example.com/auth", "https://example.com/token") // want `\AAccessCodeFlow should be replaced with AuthorizationCodeFlow\z` `\Arefresh URL should be added to AuthorizationCodeFlow\z`
+	ImplicitFlow("https://example.com/auth")                                // want `\Arefresh URL should be added to ImplicitFlow\z`
+	PasswordFlow("https://example.com/token")                               // want `\Arefresh URL should be added to PasswordFlow\z`
+	ApplicationFlow("https://example.com/token")                            // want `\AApplicationFlow should be replaced with ClientCredentialsFlow\z` `\Arefresh URL should be added to ClientCredentialsFlow\z`
+	Scope("profile", "Access to the profile")
+})
+
+var _ = API("secured", func() {
+	Security(JWT)
+})
+
+var Login = Type("credentials", func() { // want `\Avariable declarations should be fixed\z`
+	Attribute("name", String)
+	Attribute("age", Integer) // want `\AInteger should be replaced with Int\z`
+})
+
+var _ = Resource("account", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for security scheme "jwt"\z`
+		Routing(GET("/")) // want `\ARouting should be replaced with HTTP\z`
+	})
+	Action("update", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for security scheme "jwt"\z`
+		Routing(POST("/")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(Login)     // want `\APayload with a type should be replaced with an inline payload using Extend\z`
+	})
+	Action("search", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "key" should be added for security scheme "query_key"\z`
+		Routing(GET("/search")) // want `\ARouting should be replaced with HTTP\z`
+		Security(QueryKey)
+	})
+	Action("missing", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/missing")) // want `\ARouting should be replaced with HTTP\z`
+		Security("missing")      // want `\Asecurity scheme cannot be resolved\z`
+	})
+	Action("health", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/health")) // want `\ARouting should be replaced with HTTP\z`
+		NoSecurity()
+	})
+})
+
+var _ = Resource("public", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	NoSecurity()            // want `\ANoSecurity in Resource should be moved to each Method\z`
+	Action("ping", func() { // want `\AAction should be replaced with Method\z` `\ANoSecurity should be added to Method\z`
+		Routing(GET("/ping")) // want `\ARouting should be replaced with HTTP\z`
+	})
+	Action("secret", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for security scheme "jwt"\z`
+		Routing(GET("/secret")) // want `\ARouting should be replaced with HTTP\z`
+		Security(JWT, func() {
+			Scope("api:read")
+			Scope("api:admin") // want `\Ascope "api:admin" is not declared in security scheme "jwt"\z`
+		})
+	})
+})
+
+var _ = Resource("status", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("check", func() { // want `\AAction should be replaced with Method\z` `\ANoSecurity should be added to Method\z`
+		Routing(GET("/status")) // want `\ARouting should be replaced with HTTP\z`
+	})
+	NoSecurity() // want `\ANoSecurity in Resource should be moved to each Method\z`
+})
+
+var _ = Resource("key", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Security(APIKey)
+	Action("rotate", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "key" should be added for security scheme "api_key"\z`
+		Routing(POST("/rotate")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(func() {
+			Attribute("reason", String)
+		})
+	})
+	Action("revoke", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "token" should be added for security scheme "jwt"\z`
+		Routing(POST("/revoke")) // want `\ARouting should be replaced with HTTP\z`
+		Security("jwt")
+	})
+	Action("authorize", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "access_token" should be added for security scheme "oauth2"\z`
+		Routing(POST("/authorize")) // want `\ARouting should be replaced with HTTP\z`
+		Security(OAuth2, func() {
+			Scope("profile")
+		})
+	})
+})
+
+var _ = Resource("basic", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Security(BasicAuth)
+	Action("login", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "username" should be added for security scheme "basic"\z` `\Apayload attribute "password" should be added for security scheme "basic"\z`
+		Routing(POST("/login")) // want `\ARouting should be replaced with HTTP\z`
+	})
+	Action("register", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "username" should be added for security scheme "basic"\z` `\Apayload attribute "password" should be added for security scheme "basic"\z`
+		Routing(POST("/register")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(Login, func() {    // want `\APayload with a type should be replaced with an inline payload using Extend\z`
+			Required("name") // want `\ARequired in Payload should be checked since it is combined with the attributes required by Login\z`
+		})
+	})
+	Action("rename", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "username" should be added for security scheme "basic"\z` `\Apayload attribute "password" should be added for security scheme "basic"\z`
+		Routing(POST("/rename")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(Login, func() {  // want `\APayload with a type should be replaced with an inline payload using Reference\z`
+			Attribute("name")
+			Required("name")
+		})
+	})
+	Action("import", func() { // want `\AAction should be replaced with Method\z`
+		Routing(POST("/import")) // want `\ARouting should be replaced with HTTP\z`
+		Payload(ArrayOf(String)) // want `\APayload with a type should be converted to an inline payload manually\z`
+	})
+})
@@ -0,0 +1,58 @@
+package trait
+
+import ( // want `\Aimport declarations should be fixed\z`
+	. "github.com/goadesign/goa/design"        // want `\A"github.com/goadesign/goa/design" should be removed\z`
+	. "github.com/goadesign/goa/design/apidsl" // want `\A"github.com/goadesign/goa/design/apidsl" should be replaced with "goa.design/goa/v3/dsl"\z`
+)
+
+var _ = API("trait", func() { // want `\Avariable declarations should be fixed\z`
+	Description("trait")
+	Trait("paginated", func() { // want `\ATrait should be removed since it is inlined in each UseTrait\z`
+		Params(func() {
+			Param("page", Integer) // want `\AInteger should be replaced with Int\z`
+		})
+	})
+	Trait("account", func() { // want `\ATrait should be removed since it is inlined in each UseTrait\z`
+		Routing(GET("/:accountID"))
+		Params(func() {
+			Param("accountID", Integer) // want `\AInteger should be replaced with Int\z`
+		})
+	})
+	Trait("described", func() { // want `\ATrait should be removed since it is inlined in each UseTrait\z`
+		Description("described by a trait")
+	})
+})
+
+var User = Type("user", func() { // want `\Avariable declarations should be fixed\z`
+	UseTrait("described") // want `\AUseTrait should be replaced with the DSL of trait "described"\z`
+	Attribute("name", String)
+})
+
+var _ = Resource("user", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/"))                  // want `\ARouting should be replaced with HTTP\z`
+		UseTrait("paginated", "described") // want `\AUseTrait should be replaced with the DSL of trait "paginated"\z` `\AUseTrait should be replaced with the DSL of trait "described"\z` `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z` `\AInteger should be replaced with Int\z`
+		Response(OK)                       // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		Routing(GET("/{id}")) // want `\ARouting should be replaced with HTTP\z`
+		UseTrait("missing")   // want `\Atrait "missing" cannot be resolved\z`
+		Response(OK)          // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("account", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	BasePath("/accounts")   // want `\ABasePath should be replaced with Path and wrapped by HTTP\z`
+	Action("show", func() { // want `\AAction should be replaced with Method\z`
+		UseTrait("account") // want `\AUseTrait should be replaced with the DSL of trait "account"\z` `\AInteger should be replaced with Int\z` `\ARouting should be replaced with HTTP\z` `\Acolons in HTTP routing DSLs should be replaced with curly braces\z` `\AParams should be replaced with Payload attributes and Param mappings in HTTP\z`
+		Response(OK)        // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})
+
+var _ = Resource("bottle", func() { // want `\Avariable declarations should be fixed\z` `\AResource should be replaced with Service\z`
+	Parent("account")       // want `\AParent should be wrapped by HTTP\z`
+	Action("list", func() { // want `\AAction should be replaced with Method\z` `\Apayload attribute "accountID" should be added for Params of Parent\z`
+		Routing(GET("/bottles")) // want `\ARouting should be replaced with HTTP\z`
+		Response(OK)             // want `\AResponse should be wrapped by HTTP\z` `\AOK should be replaced with StatusOK\z`
+	})
+})